@@ -0,0 +1,457 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"magical-arena/pkg/batch"
+	"magical-arena/pkg/history"
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"magical-arena/pkg/render"
+	"magical-arena/pkg/simulation"
+	"magical-arena/pkg/tui"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exit codes of the subcommands
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// usage is the help text printed for the help subcommand and for unknown subcommands.
+const usage = `Usage:
+  arena [--render FORMAT] [COMMAND]
+  arena                                   start the interactive menu
+  arena fight --a PLAYER --b PLAYER [--seed N] [--max-rounds N] [--format text|json] [--rules FILE] [--tui]
+  arena simulate --a PLAYER --b PLAYER [--runs N] [--seed N] [--workers N] [--format text|json] [--rules FILE]
+  arena batch [--output FILE] [--rules FILE] SPEC.json|SPEC.yaml
+                                          play every fixture of a batch file and write the results as JSON
+  arena help                              show this help
+
+A PLAYER is either "Name:health:strength:attack" or the name of a player saved in the roster.
+With --tui the fight is animated full-screen: space pauses, n steps, + and - change the speed, q quits.
+Matches played with fight and in the interactive menu are appended to the match history; batch and simulate
+report their results in their own output instead, so large runs do not flood the history.
+Rules are read from --rules, $MAGICAL_ARENA_RULES or rules.json in the configuration directory,
+and default to the standard rules of the arena.
+
+FORMAT is one of auto, ansi, plain, json or markdown. By default the output is colored on a terminal,
+and plain text when it is piped or the NO_COLOR environment variable is set to a non-empty value.
+
+Exit codes: 0 on success, 1 when the command fails, 2 on invalid usage.
+`
+
+// errUsage marks errors caused by invalid arguments, which exit with exitUsage.
+var errUsage = errors.New("invalid usage")
+
+// parseGlobalFlags reads the options that come before the subcommand.
+//
+// Parameters:
+//   - args: The command line arguments after the program name.
+//   - stderr: The writer for flag errors.
+//
+// Returns:
+//   - string: The output format chosen with --render.
+//   - []string: The remaining arguments, starting with the subcommand if there is one.
+//   - error: An error if the options are invalid.
+func parseGlobalFlags(args []string, stderr io.Writer) (string, []string, error) {
+	flagSet := flag.NewFlagSet("arena", flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	flagSet.Usage = func() {
+		fmt.Fprint(stderr, usage)
+	}
+	format := flagSet.String("render", render.FormatAuto, "output format: auto, ansi, plain, json or markdown")
+	if err := flagSet.Parse(args); err != nil {
+		return "", nil, err
+	}
+	if _, err := render.New(*format, io.Discard); err != nil {
+		fmt.Fprintln(stderr, "arena: "+err.Error())
+		return "", nil, err
+	}
+	return *format, flagSet.Args(), nil
+}
+
+// runCommand runs a subcommand of the arena with the roster, rules and match history of the session.
+//
+// Parameters:
+//   - args: The command line arguments after the program name, starting with the subcommand.
+//   - stdout: The writer for the output of the command.
+//   - stderr: The writer for error messages.
+//
+// Returns:
+//   - int: The exit code of the command.
+//
+// Example:
+//   os.Exit(s.runCommand([]string{"fight", "--a", "Hero:100:10:5", "--b", "Brute:80:8:6"}, os.Stdout, os.Stderr))
+func (s *session) runCommand(args []string, stdout, stderr io.Writer) int {
+	var err error
+	switch args[0] {
+	case "fight":
+		err = s.fightCommand(args[1:], stdout, stderr)
+	case "simulate":
+		err = s.simulateCommand(args[1:], stdout, stderr)
+	case "batch":
+		err = s.batchCommand(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errUsage):
+		fmt.Fprintln(stderr, "arena "+args[0]+": "+err.Error())
+		return exitUsage
+	default:
+		fmt.Fprintln(stderr, "arena "+args[0]+": "+err.Error())
+		return exitError
+	}
+}
+
+// matchupFlags holds the flags shared by the commands that pit two players against each other.
+type matchupFlags struct {
+	a, b    string
+	seed    int64
+	format  string
+	rules   string
+	flagSet *flag.FlagSet
+	session *session
+}
+
+// newMatchupFlags creates the flag set of a command with the --a, --b, --seed, --format and --rules flags.
+// The players and rules are resolved with the roster and rules of the session.
+//
+// Parameters:
+//   - name: The name of the command.
+//   - stderr: The writer for flag errors and help.
+//
+// Returns:
+//   - *matchupFlags: A pointer to the parsed values, filled in by parse.
+func (s *session) newMatchupFlags(name string, stderr io.Writer) *matchupFlags {
+	f := &matchupFlags{flagSet: flag.NewFlagSet(name, flag.ContinueOnError), session: s}
+	f.flagSet.SetOutput(stderr)
+	f.flagSet.StringVar(&f.a, "a", "", `Player A, as "Name:health:strength:attack" or a roster name`)
+	f.flagSet.StringVar(&f.b, "b", "", `Player B, as "Name:health:strength:attack" or a roster name`)
+	f.flagSet.Int64Var(&f.seed, "seed", 0, "seed of the dice (default: random)")
+	f.flagSet.StringVar(&f.format, "format", "text", "output format: text or json")
+	f.flagSet.StringVar(&f.rules, "rules", "", "rules file (default: the configured rules)")
+	return f
+}
+
+// parse parses the arguments of a command and resolves both players.
+//
+// Parameters:
+//   - args: The arguments of the command.
+//
+// Returns:
+//   - *player.Player: A pointer to Player A.
+//   - *player.Player: A pointer to Player B.
+//   - bool: Whether --seed was given.
+//   - error: An error wrapping errUsage if the arguments are invalid.
+func (f *matchupFlags) parse(args []string) (*player.Player, *player.Player, bool, error) {
+	if err := f.flagSet.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, nil, false, err
+		}
+		return nil, nil, false, fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if f.flagSet.NArg() > 0 {
+		return nil, nil, false, fmt.Errorf("%w: unexpected argument %q", errUsage, f.flagSet.Arg(0))
+	}
+	if f.format != "text" && f.format != "json" {
+		return nil, nil, false, fmt.Errorf("%w: unknown format %q", errUsage, f.format)
+	}
+	if err := f.session.loadRulesFlag(f.rules); err != nil {
+		return nil, nil, false, err
+	}
+
+	seeded := false
+	f.flagSet.Visit(func(fl *flag.Flag) {
+		if fl.Name == "seed" {
+			seeded = true
+		}
+	})
+
+	playerA, err := f.session.parsePlayer(f.a)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("%w: --a: %v", errUsage, err)
+	}
+	playerB, err := f.session.parsePlayer(f.b)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("%w: --b: %v", errUsage, err)
+	}
+	if err := f.session.rules.ValidatePlayers(playerA, playerB); err != nil {
+		return nil, nil, false, fmt.Errorf("%w: %v", errUsage, err)
+	}
+	return playerA, playerB, seeded, nil
+}
+
+// loadRulesFlag replaces the rules of the session with the rules file given on the command line, if any.
+//
+// Parameters:
+//   - path: The path given with --rules, or empty to keep the configured rules.
+//
+// Returns:
+//   - error: An error wrapping errUsage if the rules file cannot be loaded.
+func (s *session) loadRulesFlag(path string) error {
+	if path == "" {
+		return nil
+	}
+	loaded, err := match.LoadRules(path)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	s.rules = loaded
+	return nil
+}
+
+// parsePlayer creates a player from a "Name:health:strength:attack" spec, or looks up a bare name in the roster.
+// The name may itself contain colons, since the attributes are taken from the end of the spec.
+//
+// Parameters:
+//   - spec: The player spec.
+//
+// Returns:
+//   - *player.Player: A pointer to the player.
+//   - error: An error if the spec is invalid or the name is not in the roster.
+func (s *session) parsePlayer(spec string) (*player.Player, error) {
+	if spec == "" {
+		return nil, errors.New("a player is required")
+	}
+
+	fields := strings.Split(spec, ":")
+	if len(fields) == 1 {
+		if s.fighters == nil {
+			return nil, fmt.Errorf("%q is not in the form Name:health:strength:attack and the roster is not available", spec)
+		}
+		p, err := s.fighters.Get(spec)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", spec, err)
+		}
+		return p, nil
+	}
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("%q is not in the form Name:health:strength:attack", spec)
+	}
+
+	name := strings.Join(fields[:len(fields)-3], ":")
+	attributes := make([]int, 3)
+	for i, field := range fields[len(fields)-3:] {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number in %q", field, spec)
+		}
+		attributes[i] = value
+	}
+	return player.NewPlayer(name, attributes[0], attributes[1], attributes[2]), nil
+}
+
+// fightCommand runs a single match between two players and prints every round and the result,
+// or animates it full-screen with --tui when stdout is a terminal. The match is appended to the match history.
+//
+// Parameters:
+//   - args: The arguments of the command.
+//   - stdout: The writer for the output of the command.
+//   - stderr: The writer for flag errors and warnings.
+//
+// Returns:
+//   - error: An error, if any.
+func (s *session) fightCommand(args []string, stdout, stderr io.Writer) error {
+	flags := s.newMatchupFlags("fight", stderr)
+	maxRounds := flags.flagSet.Int("max-rounds", 0, "maximum number of rounds before a draw (default: the limit of the rules)")
+	fullScreen := flags.flagSet.Bool("tui", false, "animate the fight full-screen")
+	playerA, playerB, seeded, err := flags.parse(args)
+	if err != nil {
+		return err
+	}
+	if *maxRounds < 0 {
+		return fmt.Errorf("%w: --max-rounds must not be negative", errUsage)
+	}
+	if *fullScreen && flags.format != "text" {
+		return fmt.Errorf("%w: --tui only supports the text format", errUsage)
+	}
+	if *fullScreen && !render.IsTerminal(stdout) {
+		fmt.Fprintln(stderr, "warning: --tui needs a terminal, printing the rounds instead")
+		*fullScreen = false
+	}
+
+	opts := []match.Option{match.WithRules(s.rules)}
+	if *maxRounds > 0 {
+		opts = append(opts, match.WithMaxRounds(*maxRounds))
+	}
+	if seeded {
+		opts = append(opts, match.WithSeed(flags.seed))
+	}
+	currentMatch := match.NewMatch(playerA, playerB, opts...)
+	var events []match.RoundEvent
+	var result match.Result
+	if *fullScreen {
+		result = watchMatch(currentMatch, stdout)
+		events = currentMatch.RoundEvents()
+	} else {
+		events, result = match.ConductMatch(currentMatch)
+	}
+
+	entry := history.NewEntry(currentMatch)
+	if err := s.appendHistory(entry); err != nil {
+		fmt.Fprintln(stderr, "warning: "+err.Error())
+	}
+
+	if flags.format == "json" {
+		return json.NewEncoder(stdout).Encode(entry)
+	}
+	out, _ := render.New(s.format, stdout)
+	out.Message(render.Text, fmt.Sprintf("Seed: %d", currentMatch.Seed()))
+	if !*fullScreen {
+		for _, event := range events {
+			out.Round(event)
+		}
+	}
+	out.Result(result)
+	return nil
+}
+
+// watchMatch animates a match full-screen until it is over, quit or interrupted.
+// Keys are read from standard input in raw mode; when it is not a terminal the match plays on its own.
+//
+// Parameters:
+//   - currentMatch: A pointer to the match to animate.
+//   - stdout: The terminal to draw on.
+//
+// Returns:
+//   - match.Result: The result of the match.
+func watchMatch(currentMatch *match.Match, stdout io.Writer) match.Result {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var keys io.Reader
+	if restore, err := tui.RawMode(); err == nil {
+		defer restore()
+		keys = os.Stdin
+	}
+	return tui.New(currentMatch, stdout).Run(ctx, keys)
+}
+
+// simulateCommand plays a batch of matches between two players and prints the win rates and round statistics.
+// The simulated matches are not added to the match history.
+//
+// Parameters:
+//   - args: The arguments of the command.
+//   - stdout: The writer for the output of the command.
+//   - stderr: The writer for flag errors.
+//
+// Returns:
+//   - error: An error, if any.
+func (s *session) simulateCommand(args []string, stdout, stderr io.Writer) error {
+	flags := s.newMatchupFlags("simulate", stderr)
+	runs := flags.flagSet.Int("runs", 1000, "number of matches to play")
+	workers := flags.flagSet.Int("workers", 0, "number of matches played concurrently (default: one per CPU)")
+	playerA, playerB, seeded, err := flags.parse(args)
+	if err != nil {
+		return err
+	}
+	if *runs <= 0 {
+		return fmt.Errorf("%w: --runs must be greater than 0", errUsage)
+	}
+	if *workers < 0 {
+		return fmt.Errorf("%w: --workers must not be negative", errUsage)
+	}
+
+	config := simulation.Config{Runs: *runs, Workers: *workers, Seed: flags.seed, Options: []match.Option{match.WithRules(s.rules)}}
+	if !seeded {
+		config.Seed = time.Now().UnixNano()
+	}
+	report := simulation.Run(playerA, playerB, config)
+
+	if flags.format == "json" {
+		return json.NewEncoder(stdout).Encode(report)
+	}
+	nameA, _, _, _ := player.GetPlayerBaseAttributes(playerA)
+	nameB, _, _, _ := player.GetPlayerBaseAttributes(playerB)
+	out, _ := render.New(s.format, stdout)
+	out.Message(render.Title, fmt.Sprintf("Runs: %d (seed %d)", report.Runs, config.Seed))
+	out.Message(render.Text, fmt.Sprintf("%s wins: %d (%.1f%%, 95%% CI %.1f%%-%.1f%%)", nameA, report.WinsA,
+		report.WinRateA*100, report.WinRateAInterval.Low*100, report.WinRateAInterval.High*100))
+	out.Message(render.Text, fmt.Sprintf("%s wins: %d (%.1f%%, 95%% CI %.1f%%-%.1f%%)", nameB, report.WinsB,
+		report.WinRateB*100, report.WinRateBInterval.Low*100, report.WinRateBInterval.High*100))
+	out.Message(render.Text, fmt.Sprintf("Draws: %d, stalemates: %d", report.Draws, report.Stalemates))
+	out.Message(render.Text, fmt.Sprintf("Rounds: min %d, max %d, mean %.1f", report.MinRounds, report.MaxRounds, report.MeanRounds))
+	return nil
+}
+
+// batchCommand plays every fixture of a batch file, in JSON or in YAML when its extension is .yaml or .yml,
+// and writes the results document as JSON. The matches are reported in the results document only, not in
+// the match history.
+//
+// Parameters:
+//   - args: The arguments of the command.
+//   - stdout: The writer for the results, unless --output is given.
+//   - stderr: The writer for flag errors.
+//
+// Returns:
+//   - error: An error, if any.
+func (s *session) batchCommand(args []string, stdout, stderr io.Writer) error {
+	flagSet := flag.NewFlagSet("batch", flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	output := flagSet.String("output", "", "file to write the results to (default: standard output)")
+	rulesPath := flagSet.String("rules", "", "rules file (default: the configured rules)")
+	if err := flagSet.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if err := s.loadRulesFlag(*rulesPath); err != nil {
+		return err
+	}
+	if flagSet.NArg() != 1 {
+		return fmt.Errorf("%w: expected exactly one batch file", errUsage)
+	}
+	path := flagSet.Arg(0)
+	decode := batch.Decode
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		decode = batch.DecodeYAML
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	spec, err := decode(file)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", errUsage, path, err)
+	}
+	results, err := batch.Run(spec, s.rules)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", errUsage, path, err)
+	}
+
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if *output != "" {
+		return os.WriteFile(*output, data, 0o644)
+	}
+	_, err = stdout.Write(data)
+	return err
+}
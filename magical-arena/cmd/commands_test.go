@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"magical-arena/pkg/batch"
+	"magical-arena/pkg/history"
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/render"
+	"magical-arena/pkg/roster"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ANSI escape codes for text color
+const (
+	redColor   = "\033[31m"
+	greenColor = "\033[32m"
+	resetColor = "\033[0m"
+)
+
+// testSession creates a session with no input, the default rules and the match history in a temporary directory.
+func testSession(t *testing.T, opts ...sessionOption) *session {
+	opts = append([]sessionOption{withHistory(history.Open(filepath.Join(t.TempDir(), "history.jsonl")))}, opts...)
+	return newSession(strings.NewReader(""), render.NewPlain(io.Discard), opts...)
+}
+
+// runForTest runs a subcommand in a new test session, returning its exit code and output.
+func runForTest(t *testing.T, args ...string) (int, string, string) {
+	return runInSession(testSession(t), args...)
+}
+
+// runInSession runs a subcommand in the given session, returning its exit code and output.
+func runInSession(s *session, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := s.runCommand(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// TestFightCommand tests the fight subcommand in both output formats and its exit codes.
+func TestFightCommand(t *testing.T) {
+	// TEST 1: a seeded text fight prints its seed, every round and the result, and is stored in the history
+	s := testSession(t)
+	code, stdout, _ := runInSession(s, "fight", "--a", "Hero:100:10:5", "--b", "Brute:80:8:6", "--seed", "3")
+	entries, _ := s.history.Read()
+	if code != exitOK || !strings.HasPrefix(stdout, "Seed: 3\n") || !strings.Contains(stdout, "Match result: ") || len(entries) != 1 {
+		t.Errorf(redColor+"Unexpected fight %d: %q"+resetColor, code, stdout)
+	} else {
+		fmt.Println(greenColor + "TestFightCommand : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: the json format prints a single history entry, and the same seed gives the same match
+	code, stdout, _ = runForTest(t, "fight", "--a", "Hero:100:10:5", "--b", "Brute:80:8:6", "--seed", "3", "--format", "json")
+	var entry history.Entry
+	err := json.Unmarshal([]byte(stdout), &entry)
+	if code != exitOK || err != nil || entry.Seed != 3 || entry.Outcome != match.Win || len(entry.Rounds) != len(entries[0].Rounds) {
+		t.Errorf(redColor+"Unexpected json fight %d: %v %q"+resetColor, code, err, stdout)
+	} else {
+		fmt.Println(greenColor + "TestFightCommand : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: invalid players, formats and commands exit with the usage code
+	invalid := [][]string{
+		{"fight", "--a", "Hero:100:10:5"},
+		{"fight", "--a", "Hero:100:10", "--b", "Brute:80:8:6"},
+		{"fight", "--a", "Hero:100:10:x", "--b", "Brute:80:8:6"},
+		{"fight", "--a", "Hero:100:10:1", "--b", "Brute:80:8:6"},
+		{"fight", "--a", "Hero:100:10:5", "--b", "Brute:80:8:6", "--format", "xml"},
+		{"fight", "--unknown"},
+		{"brawl"},
+	}
+	for _, args := range invalid {
+		if code, _, stderr := runForTest(t, args...); code != exitUsage || stderr == "" {
+			t.Errorf(redColor+"Expected usage error for %v, got %d"+resetColor, args, code)
+			return
+		}
+	}
+	fmt.Println(greenColor + "TestFightCommand : Test3 : Passed" + resetColor)
+
+	// TEST 4: a rules file changes the validation thresholds and the round limit, and a broken one is a usage error
+	dir := t.TempDir()
+	strict, limited, broken := filepath.Join(dir, "strict.json"), filepath.Join(dir, "limited.json"), filepath.Join(dir, "broken.json")
+	os.WriteFile(strict, []byte(`{"minHealth": 500}`), 0o644)
+	os.WriteFile(limited, []byte(`{"maxRounds": 1}`), 0o644)
+	os.WriteFile(broken, []byte(`{"diceFaces": -1}`), 0o644)
+	strictCode, _, _ := runForTest(t, "fight", "--a", "Hero:100:10:5", "--b", "Brute:80:8:6", "--rules", strict)
+	limitedCode, stdout, _ := runForTest(t, "fight", "--a", "Hero:100:10:5", "--b", "Brute:80:8:6", "--rules", limited)
+	brokenCode, _, _ := runForTest(t, "fight", "--a", "Hero:100:10:5", "--b", "Brute:80:8:6", "--rules", broken)
+	if strictCode != exitUsage || limitedCode != exitOK || !strings.Contains(stdout, "Draw after 1 rounds") || brokenCode != exitUsage {
+		t.Errorf(redColor+"Unexpected rules results %d %d %d: %q"+resetColor, strictCode, limitedCode, brokenCode, stdout)
+	} else {
+		fmt.Println(greenColor + "TestFightCommand : Test4 : Passed" + resetColor)
+	}
+
+	// TEST 5: --tui to an output that is not a terminal prints the rounds without any escape codes
+	code, stdout, stderr := runForTest(t, "fight", "--a", "Hero:100:10:5", "--b", "Brute:80:8:6", "--seed", "3", "--tui")
+	if code != exitOK || strings.Contains(stdout, "\033[") || !strings.Contains(stdout, "Match result: ") || !strings.Contains(stderr, "--tui needs a terminal") {
+		t.Errorf(redColor+"Unexpected fight with --tui %d: %q %q"+resetColor, code, stdout, stderr)
+	} else {
+		fmt.Println(greenColor + "TestFightCommand : Test5 : Passed" + resetColor)
+	}
+
+	// TEST 6: the same roster player on both sides is a usage error, and nothing is added to the history
+	store, err := roster.Open(filepath.Join(t.TempDir(), "roster.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Create("Hero", 100, 10, 5)
+	s = testSession(t, withRoster(store))
+	code, _, stderr = runInSession(s, "fight", "--a", "Hero", "--b", "Hero", "--seed", "1")
+	entries, _ = s.history.Read()
+	if code != exitUsage || !strings.Contains(stderr, "must be different players") || len(entries) != 0 {
+		t.Errorf(redColor+"Unexpected fight against themselves %d: %q"+resetColor, code, stderr)
+	} else {
+		fmt.Println(greenColor + "TestFightCommand : Test6 : Passed" + resetColor)
+	}
+}
+
+// TestSimulateCommand tests the simulate subcommand.
+func TestSimulateCommand(t *testing.T) {
+	// TEST 1: the json report counts every run
+	code, stdout, _ := runForTest(t, "simulate", "--a", "Hero:100:10:5", "--b", "Brute:80:8:6", "--runs", "50", "--seed", "1", "--format", "json")
+	var report struct{ Runs, WinsA, WinsB int }
+	err := json.Unmarshal([]byte(stdout), &report)
+	if code != exitOK || err != nil || report.Runs != 50 || report.WinsA+report.WinsB != 50 {
+		t.Errorf(redColor+"Unexpected simulation %d: %v %q"+resetColor, code, err, stdout)
+	} else {
+		fmt.Println(greenColor + "TestSimulateCommand : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: the text report names both players, and a run count of zero is a usage error
+	code, stdout, _ = runForTest(t, "simulate", "--a", "Hero:100:10:5", "--b", "Brute:80:8:6", "--runs", "20")
+	badCode, _, _ := runForTest(t, "simulate", "--a", "Hero:100:10:5", "--b", "Brute:80:8:6", "--runs", "0")
+	if code != exitOK || !strings.Contains(stdout, "Hero wins: ") || !strings.Contains(stdout, "Brute wins: ") || badCode != exitUsage {
+		t.Errorf(redColor+"Unexpected simulation %d/%d: %q"+resetColor, code, badCode, stdout)
+	} else {
+		fmt.Println(greenColor + "TestSimulateCommand : Test2 : Passed" + resetColor)
+	}
+}
+
+// TestBatchCommand tests the batch subcommand.
+func TestBatchCommand(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "batch.json")
+	output := filepath.Join(dir, "results.json")
+	err := os.WriteFile(spec, []byte(`{
+		"players": [{"name": "Hero", "health": 100, "strength": 10, "attack": 5}, {"name": "Brute", "health": 80, "strength": 8, "attack": 6}],
+		"fixtures": [{"id": "opener", "a": "Hero", "b": "Brute", "repeat": 2, "seed": 1}]
+	}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// TEST 1: the results document references the fixture and holds every match
+	code, _, stderr := runForTest(t, "batch", "--output", output, spec)
+	data, _ := os.ReadFile(output)
+	var results batch.Results
+	err = json.Unmarshal(data, &results)
+	if code != exitOK || err != nil || len(results.Fixtures) != 1 || results.Fixtures[0].Fixture != "opener" || len(results.Fixtures[0].Matches) != 2 {
+		t.Errorf(redColor+"Unexpected batch %d: %v %q %s"+resetColor, code, err, stderr, data)
+	} else {
+		fmt.Println(greenColor + "TestBatchCommand : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: a missing file fails, and a missing argument or an invalid spec is a usage error
+	invalid := filepath.Join(dir, "invalid.yaml")
+	os.WriteFile(invalid, []byte("players: [Hero]\n"), 0o644)
+	missing, _, _ := runForTest(t, "batch", filepath.Join(dir, "missing.json"))
+	noArgs, _, _ := runForTest(t, "batch")
+	bad, _, _ := runForTest(t, "batch", invalid)
+	if missing != exitError || noArgs != exitUsage || bad != exitUsage {
+		t.Errorf(redColor+"Unexpected exit codes %d %d %d"+resetColor, missing, noArgs, bad)
+	} else {
+		fmt.Println(greenColor + "TestBatchCommand : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: a YAML batch file gives the same results as its JSON form, and no batch match is added to the history
+	yamlSpec := filepath.Join(dir, "batch.yml")
+	os.WriteFile(yamlSpec, []byte("players:\n  - name: Hero\n    health: 100\n    strength: 10\n    attack: 5\n"+
+		"  - name: Brute\n    health: 80\n    strength: 8\n    attack: 6\nfixtures:\n"+
+		"  - id: opener\n    a: Hero\n    b: Brute\n    repeat: 2\n    seed: 1\n"), 0o644)
+	s := testSession(t)
+	code, stdout, stderr := runInSession(s, "batch", yamlSpec)
+	entries, _ := s.history.Read()
+	if code != exitOK || stdout != string(data) || len(entries) != 0 {
+		t.Errorf(redColor+"Unexpected YAML batch %d: %q %q"+resetColor, code, stdout, stderr)
+	} else {
+		fmt.Println(greenColor + "TestBatchCommand : Test3 : Passed" + resetColor)
+	}
+}
+
+// TestRenderFlag tests choosing the output format with --render.
+func TestRenderFlag(t *testing.T) {
+	// TEST 1: the global options are read before the subcommand, and unknown formats are rejected
+	parsedFormat, args, err := parseGlobalFlags([]string{"--render", "markdown", "fight", "--seed", "1"}, &bytes.Buffer{})
+	_, _, badErr := parseGlobalFlags([]string{"--render", "html"}, &bytes.Buffer{})
+	if err != nil || parsedFormat != "markdown" || len(args) != 3 || args[0] != "fight" || badErr == nil {
+		t.Errorf(redColor+"Unexpected global flags %v, %q, %v"+resetColor, args, parsedFormat, badErr)
+	} else {
+		fmt.Println(greenColor + "TestRenderFlag : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: output that is not a terminal is plain by default, and markdown renders the rounds as a table
+	_, plainOut, _ := runForTest(t, "fight", "--a", "Hero:100:10:5", "--b", "Brute:80:8:6", "--seed", "3")
+	code, markdownOut, _ := runInSession(testSession(t, withFormat("markdown")), "fight", "--a", "Hero:100:10:5", "--b", "Brute:80:8:6", "--seed", "3")
+	if strings.Contains(plainOut, "\033[") || code != exitOK || !strings.Contains(markdownOut, "| Round | Attacker |") ||
+		!strings.Contains(markdownOut, "**Match result:** ") {
+		t.Errorf(redColor+"Unexpected output %q / %q"+resetColor, plainOut, markdownOut)
+	} else {
+		fmt.Println(greenColor + "TestRenderFlag : Test2 : Passed" + resetColor)
+	}
+}
+
+// TestMain runs the main testing suite.
+func TestMain(m *testing.M) {
+	fmt.Println("Testing cmd package...")
+	Result := m.Run()
+	fmt.Println("Testing complete.")
+	os.Exit(Result)
+}
@@ -2,87 +2,278 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"magical-arena/pkg/history"
 	"magical-arena/pkg/match"
 	"magical-arena/pkg/player"
+	"magical-arena/pkg/render"
+	"magical-arena/pkg/roster"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
 
-// different color schemes for terminal output
-const (
-	redColor     = "\033[31m"
-	greenColor   = "\033[32m"
-	yellowColor  = "\033[33m"
-	blueColor    = "\033[34m"
-	magentaColor = "\033[35m"
-	cyanColor    = "\033[36m"
-	resetColor   = "\033[0m"
-)
+// errInvalidInput is returned when a line of input is not a valid number.
+var errInvalidInput = errors.New("invalid input")
 
 // main is the entry point for the Magical Arena application. It presents a console-based menu
 // allowing users to enter the arena or exit the application. Once inside the arena, users can
-// choose to teleport into matches or exit back to the main menu. The main function utilizes
-// a loop to provide a continuous user interface, handling user choices and invoking relevant
-// functions for match management.
+// choose to teleport into matches or exit back to the main menu. The menu is run by a session
+// reading the standard input, so it can be driven by a terminal or by a script piped into it.
 //
-// The color-coded console output enhances the visual experience, and the application logic is
-// structured to handle various user inputs and scenarios. The ManageMatchesInArena function is
+// All output goes through a renderer: color-coded on a terminal, plain text when piped or when NO_COLOR is set,
+// or the format chosen with --render. The application logic is
+// structured to handle various user inputs and scenarios. The session's manageMatches method is
 // responsible for handling the process of entering, conducting, and managing matches within the arena.
 //
+// The exit code is 0 when the user exits or the input ends at a menu, and 1 when the input ends
+// in the middle of entering a player or cannot be read.
 //
-//
-// Note: Ensure that necessary color constants, getUserInput, and ManageMatchesInArena are defined
-// for the proper functioning of this application.
+// When a subcommand such as fight or simulate is given, it is run instead of the menu and its exit code
+// is returned to the shell.
 func main() {
+	//reading the global options that come before the subcommand
+	format, args, err := parseGlobalFlags(os.Args[1:], os.Stderr)
+	if err != nil {
+		os.Exit(exitUsage)
+	}
+	out, _ := render.New(format, os.Stdout)
+	opts := []sessionOption{withFormat(format), withHistory(history.Open(dataPath("MAGICAL_ARENA_HISTORY", "history.jsonl")))}
+
+	//loading the roster of saved players, the arena still works without it
+	store, err := roster.Open(dataPath("MAGICAL_ARENA_ROSTER", "roster.json"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error loading the roster: "+err.Error())
+	} else {
+		opts = append(opts, withRoster(store))
+	}
+
+	//loading the rules file if there is one, the default rules apply otherwise
+	if loaded, err := match.LoadRules(dataPath("MAGICAL_ARENA_RULES", "rules.json")); err == nil {
+		opts = append(opts, withRules(loaded))
+	} else if !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintln(os.Stderr, "Error loading the rules: "+err.Error())
+		os.Exit(exitError)
+	}
+	s := newSession(os.Stdin, out, opts...)
+
+	//running a subcommand instead of the interactive menu
+	if len(args) > 0 {
+		os.Exit(s.runCommand(args, os.Stdout, os.Stderr))
+	}
+
+	os.Exit(s.run())
+}
+
+// session is an interactive menu session or a subcommand run. It reads the user's input line by line from
+// a single buffered reader, so that input piped from a file or another program is never lost between prompts,
+// and writes everything through a renderer. It also holds the roster, the rules and the match history the
+// matches are played with.
+type session struct {
+	in  *bufio.Reader
+	out render.Renderer
+
+	// format is the output format chosen with --render, empty to choose automatically.
+	format string
+
+	// fighters is the roster of saved players, or nil when it is not available.
+	fighters *roster.Store
+
+	// rules are the game rules every match is played by.
+	rules match.Rules
+
+	// history is the log every completed match is appended to, or nil when matches are not recorded.
+	history *history.Log
+}
+
+// sessionOption is a function that modifies a session.
+type sessionOption func(*session)
+
+// withFormat sets the output format of the subcommands, as chosen with --render.
+//
+// Parameters:
+//   - format: The output format, empty to choose automatically.
+//
+// Returns:
+//   - sessionOption: The option setting the format.
+func withFormat(format string) sessionOption {
+	return func(s *session) {
+		s.format = format
+	}
+}
+
+// withRoster sets the roster of saved players. Without it the roster is not available.
+//
+// Parameters:
+//   - store: A pointer to the roster.
+//
+// Returns:
+//   - sessionOption: The option setting the roster.
+func withRoster(store *roster.Store) sessionOption {
+	return func(s *session) {
+		s.fighters = store
+	}
+}
+
+// withRules sets the game rules. Without it the default rules apply.
+//
+// Parameters:
+//   - rules: The rules the matches are played by.
+//
+// Returns:
+//   - sessionOption: The option setting the rules.
+func withRules(rules match.Rules) sessionOption {
+	return func(s *session) {
+		s.rules = rules
+	}
+}
+
+// withHistory sets the match history. Without it matches are not recorded.
+//
+// Parameters:
+//   - log: A pointer to the match history.
+//
+// Returns:
+//   - sessionOption: The option setting the match history.
+func withHistory(log *history.Log) sessionOption {
+	return func(s *session) {
+		s.history = log
+	}
+}
+
+// newSession creates a session reading the user's input from in and rendering its output with out.
+//
+// Parameters:
+//   - in: The reader to read the user's input from, usually os.Stdin.
+//   - out: The renderer to write the menus and matches with.
+//   - opts: Optional settings such as withRoster, withRules or withHistory.
+//
+// Returns:
+//   - *session: A pointer to the newly created session.
+//
+// Example:
+//   s := newSession(strings.NewReader("1\n0\n0\n"), render.NewPlain(os.Stdout), withRules(match.DefaultRules()))
+//   os.Exit(s.run())
+func newSession(in io.Reader, out render.Renderer, opts ...sessionOption) *session {
+	s := &session{in: bufio.NewReader(in), out: out, rules: match.DefaultRules()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// run presents the main menu until the user exits or the input ends.
+//
+// Returns:
+//   - int: The exit code of the session: exitOK when the user exits or the input ends at a menu,
+//     exitError when the input ends in the middle of entering a player or cannot be read.
+//
+// Example:
+//   os.Exit(newSession(os.Stdin, render.Auto(os.Stdout)).run())
+func (s *session) run() int {
 	for {
-		fmt.Println(cyanColor + "Welcome to Magical Arena 1.0!" + resetColor)
-		fmt.Println(magentaColor + "Press 1 to enter the arena or press 0 to exit" + resetColor)
+		s.out.Message(render.Title, "Welcome to Magical Arena 1.0!")
+		s.out.Message(render.Notice, "Press 1 to enter the arena, press 2 to manage the roster or press 0 to exit")
 
-		choice, err := getUserInput("Enter your choice: ")
-		if err != nil {
-			fmt.Println(redColor + "Please enter a valid choice or press 0 to exit" + resetColor)
+		choice, err := s.getUserInput("Enter your choice: ")
+		if errors.Is(err, errInvalidInput) {
+			s.out.Message(render.Error, "Please enter a valid choice or press 0 to exit")
 			continue
 		}
+		if err != nil {
+			return s.end(err)
+		}
 
 		switch choice {
 		case 0:
-			fmt.Println(redColor + "Exiting the application. Goodbye!" + resetColor)
-			return
+			s.out.Message(render.Notice, "Exiting the application. Goodbye!")
+			return exitOK
 		case 1:
-			fmt.Println(magentaColor + "Entering the arena..." + resetColor)
-			fmt.Println(cyanColor + "Welcome to the arena!" + resetColor)
-			fmt.Println(yellowColor + "Press 1 to teleport into matches or press 0 to exit" + resetColor)
+			err = s.enterArena()
+		case 2:
+			err = s.manageRoster()
+		default:
+			s.out.Message(render.Error, "Invalid choice. Please enter 0, 1 or 2.")
+		}
+		if err != nil {
+			return s.end(err)
+		}
+	}
+}
 
-			//take user input to enter a match or exit the application
-			choice, err := getUserInput("Enter your choice: ")
+// end reports why the input stopped and returns the exit code of the session.
+//
+// Parameters:
+//   - err: The error that stopped the session: io.EOF when the input ended at a menu.
+//
+// Returns:
+//   - int: exitOK if the input ended at a menu, exitError otherwise.
+func (s *session) end(err error) int {
+	switch {
+	case err == io.EOF:
+		s.out.Message(render.Notice, "End of input. Goodbye!")
+		return exitOK
+	case errors.Is(err, io.EOF):
+		s.out.Message(render.Error, "Input ended unexpectedly: "+err.Error())
+	default:
+		s.out.Message(render.Error, "Error reading user input: "+err.Error())
+	}
+	return exitError
+}
 
-			//entering inside matches
-			if choice == 1 {
-				// this function will handle the logic of starting matches and concluding them
-				ManageMatchesInArena()
-			}
+// enterArena presents the arena menu, from which the user can teleport into matches or go back to the main menu.
+//
+// Returns:
+//   - error: An error if the input ended or could not be read, nil otherwise.
+func (s *session) enterArena() error {
+	s.out.Message(render.Notice, "Entering the arena...")
+	s.out.Message(render.Title, "Welcome to the arena!")
+	s.out.Message(render.Menu, "Press 1 to teleport into matches or press 0 to exit")
+
+	//take user input to enter a match or exit the application
+	choice, err := s.getUserInput("Enter your choice: ")
+	if err != nil && !errors.Is(err, errInvalidInput) {
+		return err
+	}
 
-			if err != nil {
-				fmt.Println(redColor + "Error reading user input: " + err.Error() + resetColor)
-				continue
-			}
+	switch {
+	case err != nil:
+		s.out.Message(render.Error, "Invalid choice. Returning to the main menu.")
+	case choice == 1:
+		// this method will handle the logic of starting matches and concluding them
+		return s.manageMatches()
+	case choice == 0:
+		s.out.Message(render.Notice, "Exiting the arena.")
+	default:
+		s.out.Message(render.Error, "Invalid choice. Returning to the main menu.")
+	}
+	return nil
+}
 
-			//handled arena exiting logic
-			if choice == 0 {
-				fmt.Println(magentaColor + "Exiting the arena." + resetColor)
-			} else {
-				fmt.Println(redColor + "Invalid choice. Returning to the main menu." + resetColor)
-			}
-		default:
-			fmt.Println(redColor + "Invalid choice. Please enter 0 or 1." + resetColor)
-		}
+// dataPath returns the path of one of the application's data files: the environment variable if set, otherwise
+// the file in magical-arena in the user's configuration directory, or in the working directory.
+//
+// Parameters:
+//   - envVar: The environment variable that overrides the path.
+//   - fileName: The name of the data file.
+//
+// Returns:
+//   - string: The path of the data file.
+func dataPath(envVar, fileName string) string {
+	if path := os.Getenv(envVar); path != "" {
+		return path
 	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, "magical-arena", fileName)
+	}
+	return fileName
 }
 
 // getUserInput prompts the user with the provided message, reads their input
-// from the standard input, trims leading/trailing whitespaces, converts the
+// from the session's input, trims leading/trailing whitespaces, converts the
 // input to an integer, and returns the parsed integer choice.
 //
 // Parameters:
@@ -90,9 +281,9 @@ func main() {
 //
 // Returns:
 //   - int: The parsed integer choice.
-//   - error: An error, if any.
-func getUserInput(prompt string) (int, error) {
-	input, err := getStringInput(prompt)
+//   - error: An error wrapping errInvalidInput if the input is not a number, or the error reading the input.
+func (s *session) getUserInput(prompt string) (int, error) {
+	input, err := s.getStringInput(prompt)
 	if err != nil {
 		return 0, err
 	}
@@ -100,136 +291,219 @@ func getUserInput(prompt string) (int, error) {
 	// Convert the input to an integer
 	choice, err := strconv.Atoi(input)
 	if err != nil {
-		return 0, fmt.Errorf("invalid input: %s", input)
+		return 0, fmt.Errorf("%w: %s", errInvalidInput, input)
 	}
 
 	return choice, nil
 }
 
-// ManageMatchesInArena initiates the process for entering and conducting matches in the arena.
+// manageMatches initiates the process for entering and conducting matches in the arena.
 //
-// This function presents the user with options to either enter a new match or exit the arena.
+// This method presents the user with options to either enter a new match or exit the arena.
 // It prompts the user for input and creates Player instances for both participants.
-// The function then validates the attributes of both players and proceeds to create and conduct a new match.
-// The match result and round records are stored in a map, and the match number is incremented for each new match.
+// The method then validates the attributes of both players and proceeds to create and conduct a new match.
+// Every completed match, with its round records, is appended to the match history.
 //
-// The function continues running until the user chooses to exit the matches section by entering 0.
+// The method continues running until the user chooses to exit the matches section by entering 0,
+// or the input ends.
 //
-// Example:
-//   ManageMatchesInArena()
+// Returns:
+//   - error: An error if the input ended or could not be read, nil otherwise.
 //
-// Note: Ensure that the necessary color constants, getUserInput, getPlayerAttributes, isValidPlayerAttributes,
-// and match packages are correctly imported and defined for the proper functioning of this function.
-func ManageMatchesInArena() {
-	matchRecords := make(map[int]string)
-	matchNo := 1
-
+// Example:
+//   err := s.manageMatches()
+func (s *session) manageMatches() error {
 	for {
-		fmt.Println(yellowColor + "Press 1 to start a match or press 0 to exit the arena" + resetColor)
+		s.out.Message(render.Menu, "Press 1 to start a match or press 0 to exit the arena")
 
-		choice, err := getUserInput("Enter your choice: ")
+		choice, err := s.getUserInput("Enter your choice: ")
+		if errors.Is(err, errInvalidInput) {
+			s.out.Message(render.Error, "Please enter a valid choice or press 0 to exit")
+			return nil
+		}
 		if err != nil {
-			fmt.Println(redColor + "Please enter a valid choice or press 0 to exit" + resetColor)
-			return
+			return err
 		}
 
 		switch choice {
 		case 0:
-			fmt.Println(magentaColor + "Exiting the matches section." + resetColor)
-			return
+			s.out.Message(render.Notice, "Exiting the matches section.")
+			return nil
 		case 1:
-			fmt.Println(cyanColor + "Entering a new match..." + resetColor)
+			s.out.Message(render.Title, "Entering a new match...")
 
-			player1, err := getPlayerAttributes("Player 1")
+			player1, err := s.getPlayerAttributes("Player 1")
+			if inputFailed(err) {
+				return fmt.Errorf("entering Player 1: %w", err)
+			}
 			if err != nil {
-				fmt.Println(redColor + "Error creating Player 1: " + err.Error() + resetColor)
+				s.out.Message(render.Error, "Error creating Player 1: "+err.Error())
 				continue
 			}
 
-			player2, err := getPlayerAttributes("Player 2")
+			player2, err := s.getPlayerAttributes("Player 2")
+			if inputFailed(err) {
+				return fmt.Errorf("entering Player 2: %w", err)
+			}
 			if err != nil {
-				fmt.Println(redColor + "Error creating Player 2: " + err.Error() + resetColor)
+				s.out.Message(render.Error, "Error creating Player 2: "+err.Error())
 				continue
 			}
 
 			// Validate players attributes
-			if !isValidPlayerAttributes(player1, player2) {
+			if !s.isValidPlayerAttributes(player1, player2) {
 				continue
 			}
 
+			//saving new players so they can be picked by name next time
+			s.rememberPlayer(player1)
+			s.rememberPlayer(player2)
+
 			// Create a new match
-			currentMatch := match.NewMatch(player1, player2)
+			currentMatch := match.NewMatch(player1, player2, match.WithRules(s.rules))
 
 			//conducting the match
 			_, matchResult := match.ConductMatch(currentMatch)
 
-			//storing the match result and match round records in map
-			matchRecords[matchNo] = matchResult
-
-			//incrementing the match number
-			matchNo++
+			//storing the match result and match round records in the history
+			if err := s.appendHistory(history.NewEntry(currentMatch)); err != nil {
+				s.out.Message(render.Error, "Error saving the match history: "+err.Error())
+			}
 
-			fmt.Println(greenColor + "Match result: " + matchResult + resetColor)
+			s.out.Result(matchResult)
 		default:
-			fmt.Println(redColor + "Invalid choice. Please enter 0 or 1." + resetColor)
+			s.out.Message(render.Error, "Invalid choice. Please enter 0 or 1.")
 		}
 	}
 }
 
-// validatePlayerAttacks checks if the attacks of two players are within valid ranges to proceed with a match.
-// It compares the attack strength of one player against the health of the other player, considering specific conditions.
+// manageRoster lets the user list, update and delete the players saved in the roster.
 //
-// Parameters:
-//   - playerAttack1: The attack strength of Player 1.
-//   - playerStrength1: The health (strength) of Player 1.
-//   - playerAttack2: The attack strength of Player 2.
-//   - playerStrength2: The health (strength) of Player 2.
+// The method continues running until the user chooses to go back by entering 0, or the input ends.
 //
 // Returns:
-//   - bool: True if the attacks are within valid ranges, false otherwise.
-func isValidPlayerAttributes(player1, player2 *player.Player) bool {
-	playerName1, playerHealth1, playerStrength1, playerAttack1 := player.GetPlayerBaseAttributes(player1)
-	playerName2, playerHealth2, playerStrength2, playerAttack2 := player.GetPlayerBaseAttributes(player2)
-
-	//check for unique names of players
-	if playerName1 == playerName2 {
-		fmt.Println(redColor + "Player names must be unique." + resetColor)
-		return false
+//   - error: An error if the input ended or could not be read, nil otherwise.
+//
+// Example:
+//   err := s.manageRoster()
+func (s *session) manageRoster() error {
+	if s.fighters == nil {
+		s.out.Message(render.Error, "The roster is not available.")
+		return nil
 	}
 
-	//check for health must be greater than 0
-	if playerHealth1 <= 0 || playerHealth2 <= 0 {
-		fmt.Println(redColor + "Player health must be greater than 0." + resetColor)
-		return false
-	}
+	for {
+		s.out.Message(render.Menu, "Press 1 to list players, 2 to update a player, 3 to delete a player or 0 to go back")
 
-	//check for strength must be greater than 0
-	if playerStrength1 <= 0 || playerStrength2 <= 0 {
-		fmt.Println(redColor + "Player strength must be greater than 0." + resetColor)
-		return false
+		choice, err := s.getUserInput("Enter your choice: ")
+		if errors.Is(err, errInvalidInput) {
+			s.out.Message(render.Error, "Please enter a valid choice or press 0 to go back")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch choice {
+		case 0:
+			return nil
+		case 1:
+			players := s.fighters.List()
+			if len(players) == 0 {
+				s.out.Message(render.Notice, "The roster is empty.")
+			}
+			for _, p := range players {
+				name, health, strength, attack := player.GetPlayerBaseAttributes(p)
+				s.out.Message(render.Title, fmt.Sprintf("%s: health %d, strength %d, attack %d", name, health, strength, attack))
+			}
+		case 2:
+			name, err := s.getStringInput("Name: ")
+			if err != nil {
+				return fmt.Errorf("updating a player: %w", err)
+			}
+			if _, err := s.fighters.Get(name); err != nil {
+				s.out.Message(render.Error, "Error updating "+name+": "+err.Error())
+				continue
+			}
+			health, strength, attack, err := s.getPlayerStats()
+			if inputFailed(err) {
+				return fmt.Errorf("updating %s: %w", name, err)
+			}
+			if err != nil {
+				s.out.Message(render.Error, "Error updating "+name+": "+err.Error())
+				continue
+			}
+			if _, err := s.fighters.Update(name, health, strength, attack); err != nil {
+				s.out.Message(render.Error, "Error updating "+name+": "+err.Error())
+				continue
+			}
+			s.out.Message(render.Success, "Updated "+name+".")
+		case 3:
+			name, err := s.getStringInput("Name: ")
+			if err != nil {
+				return fmt.Errorf("deleting a player: %w", err)
+			}
+			if err := s.fighters.Delete(name); err != nil {
+				s.out.Message(render.Error, "Error deleting "+name+": "+err.Error())
+				continue
+			}
+			s.out.Message(render.Success, "Deleted "+name+".")
+		default:
+			s.out.Message(render.Error, "Invalid choice. Please enter 0, 1, 2 or 3.")
+		}
 	}
+}
 
-	//check for attack must be greater than 0
-	if playerAttack1 <= 0 || playerAttack2 <= 0 {
-		fmt.Println(redColor + "Player attack must be greater than 0." + resetColor)
-		return false
+// rememberPlayer saves a player to the roster unless a player with the same name is already in it.
+//
+// Parameters:
+//   - p: A pointer to the player to save.
+func (s *session) rememberPlayer(p *player.Player) {
+	if s.fighters == nil {
+		return
 	}
+	name, health, strength, attack := player.GetPlayerBaseAttributes(p)
+	if _, err := s.fighters.Get(name); err == nil {
+		return
+	}
+	if _, err := s.fighters.Create(name, health, strength, attack); err != nil {
+		s.out.Message(render.Error, "Error saving "+name+" to the roster: "+err.Error())
+	}
+}
 
-	//check for attack conditions must be following certain conditions
-	if playerAttack1*6 <= playerStrength2 {
-		fmt.Println(redColor + "Player 1 attack is too low to damage Player 2." + resetColor)
-		return false
+// appendHistory appends the entry of a played match to the match history if the session has one.
+//
+// Parameters:
+//   - entry: The entry of the match, with its round records.
+//
+// Returns:
+//   - error: An error if the match history could not be written.
+func (s *session) appendHistory(entry history.Entry) error {
+	if s.history == nil {
+		return nil
 	}
+	return s.history.Append(entry)
+}
 
-	if playerAttack2*6 <= playerStrength1 {
-		fmt.Println(redColor + "Player 2 attack is too low to damage Player 1." + resetColor)
+// isValidPlayerAttributes checks if the attributes of two players are within valid ranges to proceed with a match,
+// printing the reason when they are not.
+//
+// Parameters:
+//   - player1: A pointer to Player 1.
+//   - player2: A pointer to Player 2.
+//
+// Returns:
+//   - bool: True if the attributes are within valid ranges, false otherwise.
+func (s *session) isValidPlayerAttributes(player1, player2 *player.Player) bool {
+	if err := s.rules.ValidatePlayers(player1, player2); err != nil {
+		s.out.Message(render.Error, err.Error())
 		return false
 	}
-
 	return true
 }
 
 // getPlayerAttributes prompts the user to enter attributes for a player and returns a new Player instance.
+// If the entered name is in the roster, the saved player is used instead of asking for the attributes.
 //
 // Parameters:
 //   - playerName: The name of the player.
@@ -237,34 +511,58 @@ func isValidPlayerAttributes(player1, player2 *player.Player) bool {
 // Returns:
 //   - *player.Player: A pointer to the newly created Player instance.
 //   - error: An error, if any.
-func getPlayerAttributes(playerName string) (*player.Player, error) {
-	fmt.Printf(cyanColor+"Enter attributes for %s:\n"+resetColor, playerName)
+func (s *session) getPlayerAttributes(playerName string) (*player.Player, error) {
+	s.out.Message(render.Title, fmt.Sprintf("Enter attributes for %s:", playerName))
 
-	name, err := getStringInput("Name: ")
+	name, err := s.getStringInput("Name: ")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get player name: %w", err)
 	}
 
-	health, err := getIntegerInput("Health: ")
+	//picking a saved player by name
+	if s.fighters != nil {
+		if saved, err := s.fighters.Get(name); err == nil {
+			s.out.Message(render.Success, "Loaded "+name+" from the roster.")
+			return saved, nil
+		}
+	}
+
+	health, strength, attack, err := s.getPlayerStats()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get player health: %w", err)
+		return nil, err
 	}
 
-	strength, err := getIntegerInput("Strength: ")
+	return player.NewPlayer(name, health, strength, attack), nil
+}
+
+// getPlayerStats prompts the user to enter the health, strength and attack of a player.
+//
+// Returns:
+//   - int: The health of the player.
+//   - int: The strength of the player.
+//   - int: The attack of the player.
+//   - error: An error, if any.
+func (s *session) getPlayerStats() (int, int, int, error) {
+	health, err := s.getIntegerInput("Health: ")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get player strength: %w", err)
+		return 0, 0, 0, fmt.Errorf("failed to get player health: %w", err)
 	}
 
-	attack, err := getIntegerInput("Attack: ")
+	strength, err := s.getIntegerInput("Strength: ")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get player attack: %w", err)
+		return 0, 0, 0, fmt.Errorf("failed to get player strength: %w", err)
 	}
 
-	return player.NewPlayer(name, health, strength, attack), nil
+	attack, err := s.getIntegerInput("Attack: ")
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to get player attack: %w", err)
+	}
+
+	return health, strength, attack, nil
 }
 
 // getIntegerInput prompts the user with the provided message,
-// reads their input from the standard input, trims leading/trailing
+// reads their input from the session's input, trims leading/trailing
 // whitespaces, and converts the input to an integer.
 //
 // It relies on getStringInput to obtain user input as a string and
@@ -277,8 +575,8 @@ func getPlayerAttributes(playerName string) (*player.Player, error) {
 // Returns:
 //   - int: The parsed integer.
 //   - error: An error, if any.
-func getIntegerInput(prompt string) (int, error) {
-	input, err := getStringInput(prompt)
+func (s *session) getIntegerInput(prompt string) (int, error) {
+	input, err := s.getStringInput(prompt)
 	if err != nil {
 		return 0, err
 	}
@@ -287,26 +585,22 @@ func getIntegerInput(prompt string) (int, error) {
 	return strconv.Atoi(input)
 }
 
-//Wrapper function for getIntegerInput to be used in tests
-func ExposeGetIntegerInput(prompt string) (int, error) {
-	return getIntegerInput(prompt)
-}
-
 // getStringInput prompts the user with the provided message,
-// reads their input from the standard input, trims leading/trailing
-// whitespaces, and returns the resulting string.
+// reads a line of input from the session's reader, trims leading/trailing
+// whitespaces, and returns the resulting string. A last line without a
+// trailing newline is returned as a normal line; io.EOF is only returned
+// once no input is left.
 //
 // Parameters:
 //   - prompt: The message to prompt the user for input.
 //
 // Returns:
 //   - string: The user-input string.
-//   - error: An error, if any.
-func getStringInput(prompt string) (string, error) {
-	fmt.Print(prompt)
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
-	if err != nil {
+//   - error: io.EOF when the input has ended, or the error reading the input.
+func (s *session) getStringInput(prompt string) (string, error) {
+	s.out.Prompt(prompt)
+	input, err := s.in.ReadString('\n')
+	if err != nil && (err != io.EOF || input == "") {
 		return "", err
 	}
 
@@ -314,7 +608,8 @@ func getStringInput(prompt string) (string, error) {
 	return strings.TrimSpace(input), nil
 }
 
-//Wrapper function for getStringInput to be used in tests
-func ExposeGetStringInput(prompt string) (string, error) {
-	return getStringInput(prompt)
-}
\ No newline at end of file
+// inputFailed reports whether err comes from the input itself, which has ended or cannot be read,
+// rather than from a line of input that could not be used.
+func inputFailed(err error) bool {
+	return err != nil && !errors.As(err, new(*strconv.NumError))
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"magical-arena/pkg/history"
+	"magical-arena/pkg/render"
+	"magical-arena/pkg/roster"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// runSession runs a whole interactive session on the given input, with the default rules and the roster and
+// match history in a temporary directory, returning the session, its exit code and output.
+func runSession(t *testing.T, input string) (*session, int, string) {
+	dir := t.TempDir()
+	store, err := roster.Open(filepath.Join(dir, "roster.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	s := newSession(strings.NewReader(input), render.NewPlain(&stdout),
+		withRoster(store), withHistory(history.Open(filepath.Join(dir, "history.jsonl"))))
+	code := s.run()
+	return s, code, stdout.String()
+}
+
+// TestSession tests scripted menu sessions from start to end, including the end of the input.
+func TestSession(t *testing.T) {
+	// TEST 1: exiting from the main menu
+	_, code, stdout := runSession(t, "0\n")
+	if code != exitOK || !strings.HasSuffix(stdout, "Exiting the application. Goodbye!\n") {
+		t.Errorf(redColor+"Unexpected exit %d: %q"+resetColor, code, stdout)
+	} else {
+		fmt.Println(greenColor + "TestSession : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: empty input ends the session at the main menu instead of looping
+	_, code, stdout = runSession(t, "")
+	if code != exitOK || strings.Count(stdout, "Welcome to Magical Arena") != 1 || !strings.HasSuffix(stdout, "End of input. Goodbye!\n") {
+		t.Errorf(redColor+"Unexpected session on empty input %d: %q"+resetColor, code, stdout)
+	} else {
+		fmt.Println(greenColor + "TestSession : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: invalid choices are reported, then the end of the input ends the session
+	_, code, stdout = runSession(t, "abc\n7\n")
+	if code != exitOK || !strings.Contains(stdout, "Please enter a valid choice or press 0 to exit") ||
+		!strings.Contains(stdout, "Invalid choice. Please enter 0, 1 or 2.") || strings.Count(stdout, "Welcome to Magical Arena") != 3 {
+		t.Errorf(redColor+"Unexpected session on invalid input %d: %q"+resetColor, code, stdout)
+	} else {
+		fmt.Println(greenColor + "TestSession : Test3 : Passed" + resetColor)
+	}
+
+	// TEST 4: two matches in one session, the second picking both players from the roster, and a last line without a newline
+	s, code, stdout := runSession(t, "1\n1\n1\nHero\n100\n10\n5\nBrute\n80\n8\n6\n1\nHero\nBrute\n0\n0")
+	entries, _ := s.history.Read()
+	if code != exitOK || strings.Count(stdout, "Match result: ") != 2 || !strings.Contains(stdout, "Loaded Brute from the roster.") ||
+		len(entries) != 2 || len(s.fighters.List()) != 2 || !strings.HasSuffix(stdout, "Exiting the application. Goodbye!\n") {
+		t.Errorf(redColor+"Unexpected match session %d: %q"+resetColor, code, stdout)
+	} else {
+		fmt.Println(greenColor + "TestSession : Test4 : Passed" + resetColor)
+	}
+
+	// TEST 5: players that cannot fight are rejected and no match is played
+	s, code, stdout = runSession(t, "1\n1\n1\nHero\n100\n10\n1\nBrute\n80\n8\n6\n0\n0\n0\n")
+	entries, _ = s.history.Read()
+	if code != exitOK || !strings.Contains(stdout, "Player 1 attack is too low to damage Player 2.") || len(entries) != 0 {
+		t.Errorf(redColor+"Unexpected session with invalid players %d: %q"+resetColor, code, stdout)
+	} else {
+		fmt.Println(greenColor + "TestSession : Test5 : Passed" + resetColor)
+	}
+
+	// TEST 6: a non-numeric attribute is reported and the user can start over
+	_, code, stdout = runSession(t, "1\n1\n1\nHero\nlots\n0\n0\n")
+	if code != exitOK || !strings.Contains(stdout, "Error creating Player 1: failed to get player health:") {
+		t.Errorf(redColor+"Unexpected session with a non-numeric attribute %d: %q"+resetColor, code, stdout)
+	} else {
+		fmt.Println(greenColor + "TestSession : Test6 : Passed" + resetColor)
+	}
+
+	// TEST 7: input ending in the middle of entering a player is an error
+	_, code, stdout = runSession(t, "1\n1\n1\nHero\n100\n")
+	if code != exitError || !strings.Contains(stdout, "Input ended unexpectedly: entering Player 1: failed to get player strength: EOF") {
+		t.Errorf(redColor+"Unexpected session ending mid-match %d: %q"+resetColor, code, stdout)
+	} else {
+		fmt.Println(greenColor + "TestSession : Test7 : Passed" + resetColor)
+	}
+
+	// TEST 8: managing the roster: list, update and delete a player
+	s, code, stdout = runSession(t, "1\n1\n1\nHero\n100\n10\n5\nBrute\n80\n8\n6\n0\n2\n2\nHero\n120\n12\n6\n3\nBrute\n1\n0\n0\n")
+	players := s.fighters.List()
+	if code != exitOK || !strings.Contains(stdout, "Updated Hero.") || !strings.Contains(stdout, "Deleted Brute.") ||
+		!strings.Contains(stdout, "Hero: health 120, strength 12, attack 6") || len(players) != 1 {
+		t.Errorf(redColor+"Unexpected roster session %d: %q"+resetColor, code, stdout)
+	} else {
+		fmt.Println(greenColor + "TestSession : Test8 : Passed" + resetColor)
+	}
+
+	// TEST 9: an input that cannot be read is an error
+	var out bytes.Buffer
+	code = newSession(iotest.ErrReader(iotest.ErrTimeout), render.NewPlain(&out)).run()
+	if code != exitError || !strings.Contains(out.String(), "Error reading user input: timeout") {
+		t.Errorf(redColor+"Unexpected session on a failing input %d: %q"+resetColor, code, out.String())
+	} else {
+		fmt.Println(greenColor + "TestSession : Test9 : Passed" + resetColor)
+	}
+
+	// TEST 10: a match history that cannot be written is reported, and the session goes on
+	blocker := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(blocker, nil, 0o644)
+	out.Reset()
+	s = newSession(strings.NewReader("1\n1\n1\nHero\n100\n10\n5\nBrute\n80\n8\n6\n0\n0\n"), render.NewPlain(&out),
+		withHistory(history.Open(filepath.Join(blocker, "history.jsonl"))))
+	code = s.run()
+	if code != exitOK || !strings.Contains(out.String(), "Error saving the match history: ") || !strings.Contains(out.String(), "Match result: ") {
+		t.Errorf(redColor+"Unexpected session with a broken history %d: %q"+resetColor, code, out.String())
+	} else {
+		fmt.Println(greenColor + "TestSession : Test10 : Passed" + resetColor)
+	}
+
+	// TEST 11: picking the same roster player twice is rejected and no match is played
+	s, code, stdout = runSession(t, "1\n1\n1\nHero\n100\n10\n5\nBrute\n80\n8\n6\n1\nHero\nHero\n0\n0\n")
+	entries, _ = s.history.Read()
+	if code != exitOK || !strings.Contains(stdout, "Player 1 and Player 2 must be different players.") || len(entries) != 1 {
+		t.Errorf(redColor+"Unexpected session against themselves %d: %q"+resetColor, code, stdout)
+	} else {
+		fmt.Println(greenColor + "TestSession : Test11 : Passed" + resetColor)
+	}
+}
+
+// TestGetStringInput tests that every line is read from the same buffered input, including a last line without a newline.
+func TestGetStringInput(t *testing.T) {
+	var out bytes.Buffer
+	s := newSession(strings.NewReader("  first  \nsecond\nlast"), render.NewPlain(&out))
+
+	var lines []string
+	for {
+		line, err := s.getStringInput("> ")
+		if err != nil {
+			break
+		}
+		lines = append(lines, line)
+	}
+	if strings.Join(lines, ",") != "first,second,last" || out.String() != "> > > > " {
+		t.Errorf(redColor+"Unexpected lines %q and output %q"+resetColor, lines, out.String())
+	} else {
+		fmt.Println(greenColor + "TestGetStringInput : Test1 : Passed" + resetColor)
+	}
+}
@@ -0,0 +1,280 @@
+package batch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"time"
+)
+
+// ErrInvalidSpec is returned when a batch file is malformed or refers to unknown players.
+var ErrInvalidSpec = errors.New("invalid batch spec")
+
+// MaxRepeat is the largest number of matches a single fixture may play.
+const MaxRepeat = 100000
+
+// PlayerSpec describes a player of a batch.
+type PlayerSpec struct {
+	// Name is the name of the player, unique within the batch.
+	Name string `json:"name"`
+
+	// Health is the health attribute of the player.
+	Health int `json:"health"`
+
+	// Strength is the strength attribute of the player.
+	Strength int `json:"strength"`
+
+	// Attack is the attack attribute of the player.
+	Attack int `json:"attack"`
+}
+
+// FixtureSpec describes a pairing of two players to be played one or more times.
+type FixtureSpec struct {
+	// ID identifies the fixture in the results. It defaults to "fixture-N", N being the 1-based position of the fixture.
+	ID string `json:"id,omitempty"`
+
+	// A is the name of Player A.
+	A string `json:"a"`
+
+	// B is the name of Player B.
+	B string `json:"b"`
+
+	// Repeat is the number of matches to play, 1 when omitted and at most MaxRepeat.
+	Repeat int `json:"repeat,omitempty"`
+
+	// Seed is the seed of the first match; match k of the fixture is played with Seed+k.
+	// When omitted, every match is seeded from the current time.
+	Seed *int64 `json:"seed,omitempty"`
+
+	// MaxRounds is the round limit of every match. When omitted, the round limit of the rules applies.
+	MaxRounds int `json:"maxRounds,omitempty"`
+}
+
+// Spec is a batch of fixtures between a set of players, as read from a batch file.
+type Spec struct {
+	// Players are the players of the batch.
+	Players []PlayerSpec `json:"players"`
+
+	// Fixtures are the pairings to play, in order.
+	Fixtures []FixtureSpec `json:"fixtures"`
+}
+
+// MatchResult is the result of a single match of a fixture.
+type MatchResult struct {
+	// Seed is the seed the match was played with.
+	Seed int64 `json:"seed"`
+
+	// Outcome is the kind of result the match ended with.
+	Outcome match.Outcome `json:"outcome"`
+
+	// Winner is the name of the winning player, or empty when the match was not won.
+	Winner string `json:"winner,omitempty"`
+
+	// Rounds is the number of rounds played.
+	Rounds int `json:"rounds"`
+
+	// HealthA is Player A's health at the end of the match.
+	HealthA int `json:"healthA"`
+
+	// HealthB is Player B's health at the end of the match.
+	HealthB int `json:"healthB"`
+}
+
+// FixtureResult holds the results of every match of a fixture.
+type FixtureResult struct {
+	// Fixture is the ID of the fixture.
+	Fixture string `json:"fixture"`
+
+	// A is the name of Player A.
+	A string `json:"a"`
+
+	// B is the name of Player B.
+	B string `json:"b"`
+
+	// WinsA is the number of matches won by Player A.
+	WinsA int `json:"winsA"`
+
+	// WinsB is the number of matches won by Player B.
+	WinsB int `json:"winsB"`
+
+	// Draws is the number of matches that ended in a draw.
+	Draws int `json:"draws"`
+
+	// Stalemates is the number of matches that ended in a stalemate.
+	Stalemates int `json:"stalemates"`
+
+	// Matches holds the result of every match, in order.
+	Matches []MatchResult `json:"matches"`
+}
+
+// Results is the machine-readable results document of a batch.
+type Results struct {
+	// Fixtures holds the results of every fixture, in the order of the spec.
+	Fixtures []FixtureResult `json:"fixtures"`
+}
+
+// Decode reads a batch spec in JSON from a reader. Unknown fields are rejected, so that typos do not go unnoticed.
+//
+// Parameters:
+//   - r: The reader to decode from.
+//
+// Returns:
+//   - Spec: The decoded spec.
+//   - error: An error wrapping ErrInvalidSpec if the spec cannot be decoded.
+//
+// Example:
+//   file, _ := os.Open("batch.json")
+//   spec, err := Decode(file)
+func Decode(r io.Reader) (Spec, error) {
+	var spec Spec
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&spec); err != nil {
+		return Spec{}, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+	return spec, nil
+}
+
+// Run validates a batch spec against the rules and plays every match of every fixture by them.
+//
+// Parameters:
+//   - spec: The batch to play.
+//   - rules: The rules of every match, usually match.DefaultRules(). The seed and round limit of a fixture
+//     take precedence over them.
+//
+// Returns:
+//   - Results: The results of every fixture.
+//   - error: An error wrapping ErrInvalidSpec if the spec is invalid or a fixture's players may not fight
+//     each other under the rules; no match is played in that case.
+//
+// Example:
+//   results, err := Run(spec, match.DefaultRules())
+//   if err == nil {
+//       json.NewEncoder(os.Stdout).Encode(results)
+//   }
+func Run(spec Spec, rules match.Rules) (Results, error) {
+	if err := rules.Validate(); err != nil {
+		return Results{}, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+	players, err := spec.players()
+	if err != nil {
+		return Results{}, err
+	}
+	if err := spec.validateFixtures(players, rules); err != nil {
+		return Results{}, err
+	}
+
+	results := Results{Fixtures: make([]FixtureResult, 0, len(spec.Fixtures))}
+	for i, fixture := range spec.Fixtures {
+		results.Fixtures = append(results.Fixtures, playFixture(fixtureID(fixture, i), fixture, players[fixture.A], players[fixture.B], rules))
+	}
+	return results, nil
+}
+
+// players creates the players of the spec, keyed by name.
+func (s Spec) players() (map[string]*player.Player, error) {
+	players := make(map[string]*player.Player, len(s.Players))
+	for _, p := range s.Players {
+		switch {
+		case p.Name == "":
+			return nil, fmt.Errorf("%w: a player has no name", ErrInvalidSpec)
+		case players[p.Name] != nil:
+			return nil, fmt.Errorf("%w: player %q is listed more than once", ErrInvalidSpec, p.Name)
+		}
+		players[p.Name] = player.NewPlayer(p.Name, p.Health, p.Strength, p.Attack)
+	}
+	return players, nil
+}
+
+// validateFixtures checks that every fixture refers to two different known players who may fight each other
+// under the rules, and has sensible counts.
+func (s Spec) validateFixtures(players map[string]*player.Player, rules match.Rules) error {
+	if len(s.Fixtures) == 0 {
+		return fmt.Errorf("%w: there are no fixtures", ErrInvalidSpec)
+	}
+	ids := make(map[string]bool, len(s.Fixtures))
+	for i, fixture := range s.Fixtures {
+		id := fixtureID(fixture, i)
+		switch {
+		case ids[id]:
+			return fmt.Errorf("%w: fixture %q is listed more than once", ErrInvalidSpec, id)
+		case players[fixture.A] == nil:
+			return fmt.Errorf("%w: fixture %q: unknown player %q", ErrInvalidSpec, id, fixture.A)
+		case players[fixture.B] == nil:
+			return fmt.Errorf("%w: fixture %q: unknown player %q", ErrInvalidSpec, id, fixture.B)
+		case fixture.A == fixture.B:
+			return fmt.Errorf("%w: fixture %q: a player cannot fight themselves", ErrInvalidSpec, id)
+		case fixture.Repeat < 0:
+			return fmt.Errorf("%w: fixture %q: repeat must not be negative", ErrInvalidSpec, id)
+		case fixture.Repeat > MaxRepeat:
+			return fmt.Errorf("%w: fixture %q: repeat must be at most %d", ErrInvalidSpec, id, MaxRepeat)
+		case fixture.MaxRounds < 0:
+			return fmt.Errorf("%w: fixture %q: maxRounds must not be negative", ErrInvalidSpec, id)
+		}
+		if err := rules.ValidatePlayers(players[fixture.A], players[fixture.B]); err != nil {
+			return fmt.Errorf("%w: fixture %q: %v", ErrInvalidSpec, id, err)
+		}
+		ids[id] = true
+	}
+	return nil
+}
+
+// fixtureID returns the ID of the fixture at the given position.
+func fixtureID(fixture FixtureSpec, i int) string {
+	if fixture.ID != "" {
+		return fixture.ID
+	}
+	return fmt.Sprintf("fixture-%d", i+1)
+}
+
+// playFixture plays every match of a fixture.
+//
+// Parameters:
+//   - id: The ID of the fixture.
+//   - fixture: The fixture to play.
+//   - playerA: A pointer to Player A.
+//   - playerB: A pointer to Player B.
+//   - rules: The rules of every match.
+//
+// Returns:
+//   - FixtureResult: The results of the fixture.
+func playFixture(id string, fixture FixtureSpec, playerA, playerB *player.Player, rules match.Rules) FixtureResult {
+	repeat := fixture.Repeat
+	if repeat == 0 {
+		repeat = 1
+	}
+
+	result := FixtureResult{Fixture: id, A: fixture.A, B: fixture.B, Matches: []MatchResult{}}
+	for k := 0; k < repeat; k++ {
+		seed := time.Now().UnixNano()
+		if fixture.Seed != nil {
+			seed = *fixture.Seed + int64(k)
+		}
+		options := []match.Option{match.WithRules(rules), match.WithSeed(seed)}
+		if fixture.MaxRounds > 0 {
+			options = append(options, match.WithMaxRounds(fixture.MaxRounds))
+		}
+		currentMatch := match.NewMatch(playerA, playerB, options...)
+		_, matchResult := match.ConductMatch(currentMatch)
+		state := currentMatch.State()
+
+		played := MatchResult{Seed: seed, Outcome: matchResult.Outcome, Rounds: matchResult.Rounds, HealthA: state.HealthA, HealthB: state.HealthB}
+		switch {
+		case matchResult.Outcome == match.Win && matchResult.Winner == playerA:
+			played.Winner = fixture.A
+			result.WinsA++
+		case matchResult.Outcome == match.Win:
+			played.Winner = fixture.B
+			result.WinsB++
+		case matchResult.Outcome == match.Draw:
+			result.Draws++
+		case matchResult.Outcome == match.Stalemate:
+			result.Stalemates++
+		}
+		result.Matches = append(result.Matches, played)
+	}
+	return result
+}
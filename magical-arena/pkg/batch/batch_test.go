@@ -0,0 +1,109 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"magical-arena/pkg/match"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ANSI escape codes for text color
+const (
+	redColor   = "\033[31m"
+	greenColor = "\033[32m"
+	resetColor = "\033[0m"
+)
+
+// specJSON is a batch with two fixtures, one of them repeated.
+const specJSON = `{
+  "players": [
+    {"name": "Hero", "health": 100, "strength": 10, "attack": 5},
+    {"name": "Brute", "health": 80, "strength": 8, "attack": 6},
+    {"name": "Mage", "health": 100, "strength": 5, "attack": 10}
+  ],
+  "fixtures": [
+    {"id": "opener", "a": "Hero", "b": "Brute", "repeat": 3, "seed": 42},
+    {"a": "Brute", "b": "Mage", "seed": 7, "maxRounds": 2}
+  ]
+}`
+
+// TestRun tests decoding and playing a batch.
+func TestRun(t *testing.T) {
+	spec, err := Decode(strings.NewReader(specJSON))
+	if err != nil {
+		t.Fatalf(redColor+"Unexpected error %v"+resetColor, err)
+	}
+
+	// TEST 1: every fixture is played the requested number of times with consecutive seeds
+	results, err := Run(spec, match.DefaultRules())
+	if err != nil || len(results.Fixtures) != 2 {
+		t.Fatalf(redColor+"Unexpected results %+v, %v"+resetColor, results, err)
+	}
+	opener, second := results.Fixtures[0], results.Fixtures[1]
+	if opener.Fixture != "opener" || len(opener.Matches) != 3 || opener.Matches[2].Seed != 44 ||
+		opener.WinsA+opener.WinsB != 3 || second.Fixture != "fixture-2" || len(second.Matches) != 1 ||
+		second.Matches[0].Outcome != match.Draw || second.Draws != 1 {
+		t.Errorf(redColor+"Unexpected results %+v"+resetColor, results)
+	} else {
+		fmt.Println(greenColor + "TestRun : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: seeded batches are reproducible
+	again, _ := Run(spec, match.DefaultRules())
+	if !reflect.DeepEqual(results, again) {
+		t.Errorf(redColor + "Expected the same results for the same seeds" + resetColor)
+	} else {
+		fmt.Println(greenColor + "TestRun : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: invalid specs are rejected before any match is played
+	invalid := []string{
+		`{"players": [], "fixtures": []}`,
+		`{"players": [{"name": "Hero", "health": 100, "strength": 10, "attack": 5}], "fixtures": [{"a": "Hero", "b": "Nobody"}]}`,
+		`{"players": [{"name": "Hero", "health": 100, "strength": 10, "attack": 5}], "fixtures": [{"a": "Hero", "b": "Hero"}]}`,
+		`{"players": [{"name": "Hero", "health": 0, "strength": 10, "attack": 5}, {"name": "Brute", "health": 80, "strength": 8, "attack": 6}],
+		  "fixtures": [{"a": "Hero", "b": "Brute"}]}`,
+		`{"players": [], "fixtures": [], "rules": {}}`,
+		`{"players": [{"name": "Hero", "health": 100, "strength": 10, "attack": 5}, {"name": "Brute", "health": 80, "strength": 8, "attack": 6}],
+		  "fixtures": [{"a": "Hero", "b": "Brute", "repeat": 4611686018427387904}]}`,
+	}
+	for _, text := range invalid {
+		spec, err := Decode(strings.NewReader(text))
+		if err == nil {
+			_, err = Run(spec, match.DefaultRules())
+		}
+		if !errors.Is(err, ErrInvalidSpec) {
+			t.Errorf(redColor+"Expected ErrInvalidSpec for %s, got %v"+resetColor, text, err)
+			return
+		}
+	}
+	fmt.Println(greenColor + "TestRun : Test3 : Passed" + resetColor)
+
+	// TEST 4: fixtures are validated by the rules: their minimums, and players who cannot damage each other
+	walls, _ := Decode(strings.NewReader(`{"players": [{"name": "WallA", "health": 50, "strength": 100, "attack": 1},
+		{"name": "WallB", "health": 50, "strength": 100, "attack": 1}], "fixtures": [{"a": "WallA", "b": "WallB"}]}`))
+	_, wallsErr := Run(walls, match.DefaultRules())
+	strict := match.DefaultRules()
+	strict.MinHealth = 150
+	_, strictErr := Run(spec, strict)
+	lenient := match.DefaultRules()
+	lenient.RequireDamage, lenient.StalemateRounds = false, 5
+	wallResults, lenientErr := Run(walls, lenient)
+	if !errors.Is(wallsErr, ErrInvalidSpec) || !errors.Is(strictErr, ErrInvalidSpec) || lenientErr != nil ||
+		wallResults.Fixtures[0].Stalemates != 1 || wallResults.Fixtures[0].Matches[0].Rounds != 5 {
+		t.Errorf(redColor+"Unexpected validation %v, %v, %v, %+v"+resetColor, wallsErr, strictErr, lenientErr, wallResults)
+	} else {
+		fmt.Println(greenColor + "TestRun : Test4 : Passed" + resetColor)
+	}
+}
+
+// TestMain runs the main testing suite.
+func TestMain(m *testing.M) {
+	fmt.Println("Testing batch package...")
+	Result := m.Run()
+	fmt.Println("Testing complete.")
+	os.Exit(Result)
+}
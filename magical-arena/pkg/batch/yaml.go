@@ -0,0 +1,268 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DecodeYAML reads a batch spec in YAML from a reader. The document is converted to JSON and decoded like Decode,
+// so the same fields are accepted and unknown fields are rejected.
+//
+// Only the block style of YAML used by batch files is supported: nested mappings and sequences, plain, single-quoted
+// and double-quoted scalars, and comments. Flow collections other than [] and {}, anchors, tags and multi-line
+// scalars are rejected.
+//
+// Parameters:
+//   - r: The reader to decode from.
+//
+// Returns:
+//   - Spec: The decoded spec.
+//   - error: An error wrapping ErrInvalidSpec if the spec cannot be decoded.
+//
+// Example:
+//   file, _ := os.Open("batch.yaml")
+//   spec, err := DecodeYAML(file)
+func DecodeYAML(r io.Reader) (Spec, error) {
+	lines, err := readYAMLLines(r)
+	if err != nil {
+		return Spec{}, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+
+	var document interface{}
+	if len(lines) > 0 {
+		parser := &yamlParser{lines: lines}
+		document, err = parser.parseBlock(lines[0].indent)
+		if err == nil && parser.next < len(lines) {
+			err = fmt.Errorf("line %d: unexpected indentation", lines[parser.next].number)
+		}
+		if err != nil {
+			return Spec{}, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+		}
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return Spec{}, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+	return Decode(bytes.NewReader(data))
+}
+
+// yamlLine is a line of a YAML document holding content.
+type yamlLine struct {
+	// number is the 1-based line number, for error messages.
+	number int
+
+	// indent is the number of spaces before the content.
+	indent int
+
+	// text is the content of the line, without indentation, comment or trailing spaces.
+	text string
+}
+
+// readYAMLLines reads the lines of a YAML document that hold content, skipping blank lines, comments and
+// document markers.
+func readYAMLLines(r io.Reader) ([]yamlLine, error) {
+	var lines []yamlLine
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		raw := scanner.Text()
+		text := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed in indentation", number)
+		}
+		text = strings.TrimRight(stripComment(text), " \t")
+		if text == "" || text == "---" || text == "..." {
+			continue
+		}
+		lines = append(lines, yamlLine{number: number, indent: len(raw) - len(strings.TrimLeft(raw, " ")), text: text})
+	}
+	return lines, scanner.Err()
+}
+
+// stripComment removes a comment from a line, leaving # characters inside quoted scalars alone.
+// Quotes only count at the start of a word, so that apostrophes in plain scalars are not taken for quotes.
+func stripComment(text string) string {
+	quote := byte(0)
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && (i == 0 || text[i-1] == ' '):
+			quote = c
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return text[:i]
+		}
+	}
+	return text
+}
+
+// yamlParser builds the value of a YAML document from its lines.
+type yamlParser struct {
+	lines []yamlLine
+
+	// next is the index of the next line to parse.
+	next int
+}
+
+// parseBlock parses the mapping or sequence whose entries start at the given indentation.
+func (p *yamlParser) parseBlock(indent int) (interface{}, error) {
+	if isSequenceItem(p.lines[p.next].text) {
+		return p.parseSequence(indent)
+	}
+	return p.parseMapping(indent)
+}
+
+// parseSequence parses the items of a sequence at the given indentation.
+func (p *yamlParser) parseSequence(indent int) (interface{}, error) {
+	items := []interface{}{}
+	for p.next < len(p.lines) && p.lines[p.next].indent == indent && isSequenceItem(p.lines[p.next].text) {
+		line := p.lines[p.next]
+		rest := strings.TrimLeft(line.text[1:], " ")
+
+		var item interface{}
+		var err error
+		switch {
+		case rest == "":
+			p.next++
+			item, err = p.parseNested(line, indent)
+		case isSequenceItem(rest) || mappingKey(rest) >= 0:
+			//the item is a collection starting on the same line as the dash
+			p.lines[p.next] = yamlLine{number: line.number, indent: indent + len(line.text) - len(rest), text: rest}
+			item, err = p.parseBlock(p.lines[p.next].indent)
+		default:
+			p.next++
+			item, err = parseScalar(rest, line.number)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// parseMapping parses the entries of a mapping at the given indentation.
+func (p *yamlParser) parseMapping(indent int) (interface{}, error) {
+	entries := map[string]interface{}{}
+	for p.next < len(p.lines) && p.lines[p.next].indent == indent && !isSequenceItem(p.lines[p.next].text) {
+		line := p.lines[p.next]
+		colon := mappingKey(line.text)
+		if colon < 0 {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", line.number)
+		}
+		if strings.TrimSpace(line.text[:colon]) == "" {
+			return nil, fmt.Errorf("line %d: empty key", line.number)
+		}
+		key, err := parseScalar(line.text[:colon], line.number)
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprint(key)
+		if _, ok := entries[name]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line.number, name)
+		}
+
+		p.next++
+		var value interface{}
+		if rest := strings.TrimSpace(line.text[colon+1:]); rest != "" {
+			value, err = parseScalar(rest, line.number)
+		} else {
+			value, err = p.parseNested(line, indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries[name] = value
+	}
+	return entries, nil
+}
+
+// parseNested parses the collection under a line that ends with a colon or a dash, or returns nil if there is none.
+// A sequence under a mapping key may have the same indentation as the key.
+func (p *yamlParser) parseNested(parent yamlLine, indent int) (interface{}, error) {
+	if p.next >= len(p.lines) {
+		return nil, nil
+	}
+	child := p.lines[p.next]
+	switch {
+	case child.indent > indent:
+		return p.parseBlock(child.indent)
+	case child.indent == indent && isSequenceItem(child.text) && !isSequenceItem(parent.text):
+		return p.parseSequence(indent)
+	}
+	return nil, nil
+}
+
+// isSequenceItem reports whether a line starts a sequence item.
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// mappingKey returns the position of the colon ending the key of a mapping entry, or -1 if the line is not one.
+func mappingKey(text string) int {
+	quote := byte(0)
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case i == 0 && (c == '"' || c == '\''):
+			quote = c
+		case c == ':' && (i+1 == len(text) || text[i+1] == ' '):
+			return i
+		}
+	}
+	return -1
+}
+
+// parseScalar converts a YAML scalar to the matching JSON value: a string, a number, a boolean, nil,
+// or an empty collection.
+func parseScalar(text string, number int) (interface{}, error) {
+	switch {
+	case text == "":
+		return nil, fmt.Errorf("line %d: empty scalar", number)
+	case text == "[]":
+		return []interface{}{}, nil
+	case text == "{}":
+		return map[string]interface{}{}, nil
+	case strings.HasPrefix(text, `"`):
+		value, err := strconv.Unquote(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid double-quoted string %s", number, text)
+		}
+		return value, nil
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, fmt.Errorf("line %d: invalid single-quoted string %s", number, text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	case strings.ContainsAny(text[:1], "[{&*!|>%@`"):
+		return nil, fmt.Errorf("line %d: unsupported YAML syntax %s", number, text)
+	}
+
+	switch text {
+	case "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+	if value, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return value, nil
+	}
+	if strings.Trim(text, "0123456789.eE+-") == "" {
+		if value, err := strconv.ParseFloat(text, 64); err == nil {
+			return value, nil
+		}
+	}
+	return text, nil
+}
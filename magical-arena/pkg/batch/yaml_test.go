@@ -0,0 +1,107 @@
+package batch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestDecodeYAML tests decoding batch specs written in YAML.
+func TestDecodeYAML(t *testing.T) {
+	// TEST 1: a YAML spec decodes to the same spec as its JSON form
+	fromJSON, _ := Decode(strings.NewReader(specJSON))
+	fromYAML, err := DecodeYAML(strings.NewReader(`---
+# players of the batch
+players:
+- name: Hero          # the favourite
+  health: 100
+  strength: 10
+  attack: 5
+- name: "Brute"
+  health: 80
+  strength: 8
+  attack: 6
+-
+  name: 'Mage'
+  health: 100
+  strength: 5
+  attack: 10
+
+fixtures:
+  - id: opener
+    a: Hero
+    b: Brute
+    repeat: 3
+    seed: 42
+  - a: Brute
+    b: Mage
+    seed: 7
+    maxRounds: 2
+`))
+	if err != nil || !reflect.DeepEqual(fromYAML, fromJSON) {
+		t.Errorf(redColor+"Expected %+v, got %+v, %v"+resetColor, fromJSON, fromYAML, err)
+	} else {
+		fmt.Println(greenColor + "TestDecodeYAML : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: quoted scalars keep their text, and # only starts a comment after a space
+	spec, err := DecodeYAML(strings.NewReader("players:\n  - name: \"Hero # 1\"\n  - name: O'Brien#2 # a comment\n  - name: 'It''s me'\nfixtures: []\n"))
+	if err != nil || len(spec.Players) != 3 || spec.Players[0].Name != "Hero # 1" || spec.Players[1].Name != "O'Brien#2" || spec.Players[2].Name != "It's me" {
+		t.Errorf(redColor+"Unexpected players %+v, %v"+resetColor, spec.Players, err)
+	} else {
+		fmt.Println(greenColor + "TestDecodeYAML : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: unknown fields, unsupported syntax, duplicate keys and bad indentation are rejected
+	for i, bad := range []string{
+		"players: []\nteams: []\n",
+		"players:\n  - {name: Brute}\n",
+		"fixtures: []\nfixtures: []\n",
+		"players:\n  - name: Hero\n     health: 100\n",
+		"players:\n\t- name: Hero\n",
+		"players:\n  - : x\n",
+		": x\n",
+	} {
+		if _, err := DecodeYAML(strings.NewReader(bad)); !errors.Is(err, ErrInvalidSpec) {
+			t.Errorf(redColor+"Expected bad spec %d to be invalid, got %v"+resetColor, i, err)
+			return
+		}
+	}
+	fmt.Println(greenColor + "TestDecodeYAML : Test3 : Passed" + resetColor)
+}
+
+// FuzzDecodeYAML checks that decoding any document either succeeds or fails with ErrInvalidSpec, without panicking,
+// and that a decoded spec decodes the same from its JSON form.
+func FuzzDecodeYAML(f *testing.F) {
+	for _, seed := range []string{
+		"players:\n  - name: Hero\n    health: 100\n    strength: 10\n    attack: 5\nfixtures:\n  - a: Hero\n    b: Hero\n    seed: 1\n",
+		"players: []\nfixtures: []\n",
+		"players:\n-\n  name: 'It''s me'\n- name: \"Hero # 1\" # comment\n",
+		"---\nfixtures:\n- id: x\n  repeat: 2\n...\n",
+		"players:\n  - : x\n",
+		"players:\n  - - - a\n",
+		"\"key\": \"value\"\n",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, document string) {
+		spec, err := DecodeYAML(strings.NewReader(document))
+		if err != nil {
+			if !errors.Is(err, ErrInvalidSpec) {
+				t.Fatalf("unexpected error %v", err)
+			}
+			return
+		}
+		data, err := json.Marshal(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := Decode(strings.NewReader(string(data)))
+		if err != nil || !reflect.DeepEqual(again, spec) {
+			t.Fatalf("spec %+v decoded from its JSON form as %+v, %v", spec, again, err)
+		}
+	})
+}
@@ -0,0 +1,236 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// PlayerStats are the attributes a player started a match with.
+type PlayerStats struct {
+	// ID is the unique ID of the player.
+	ID string `json:"id"`
+
+	// Name is the name of the player.
+	Name string `json:"name"`
+
+	// Health is the starting health of the player.
+	Health int `json:"health"`
+
+	// Strength is the strength attribute of the player.
+	Strength int `json:"strength"`
+
+	// Attack is the attack attribute of the player.
+	Attack int `json:"attack"`
+}
+
+// Entry is the record of a single completed match, stored as one line of the history file.
+type Entry struct {
+	// Time is when the match was recorded.
+	Time time.Time `json:"time"`
+
+	// PlayerA holds the starting stats of Player A.
+	PlayerA PlayerStats `json:"playerA"`
+
+	// PlayerB holds the starting stats of Player B.
+	PlayerB PlayerStats `json:"playerB"`
+
+	// Seed is the seed of the match's dice.
+	Seed int64 `json:"seed"`
+
+	// Rounds holds every round of the match, in order.
+	Rounds []match.RoundEvent `json:"rounds"`
+
+	// Outcome is the kind of result the match ended with.
+	Outcome match.Outcome `json:"outcome"`
+
+	// WinnerID is the ID of the winning player, or empty when the match was not won.
+	WinnerID string `json:"winnerId,omitempty"`
+}
+
+// statsOf returns the starting stats of a player.
+func statsOf(p *player.Player) PlayerStats {
+	name, health, strength, attack := player.GetPlayerBaseAttributes(p)
+	return PlayerStats{ID: player.GetPlayerID(p), Name: name, Health: health, Strength: strength, Attack: attack}
+}
+
+// NewEntry creates the history entry of a finished match, timestamped with the current time.
+//
+// Parameters:
+//   - m: A pointer to the finished match.
+//
+// Returns:
+//   - Entry: The history entry of the match.
+//
+// Example:
+//   match.ConductMatch(currentMatch)
+//   err := log.Append(NewEntry(currentMatch))
+func NewEntry(m *match.Match) Entry {
+	result := m.Result()
+	entry := Entry{
+		Time:    time.Now().UTC(),
+		PlayerA: statsOf(m.PlayerA),
+		PlayerB: statsOf(m.PlayerB),
+		Seed:    m.Seed(),
+		Rounds:  m.RoundEvents(),
+		Outcome: result.Outcome,
+	}
+	if result.Winner != nil {
+		entry.WinnerID = player.GetPlayerID(result.Winner)
+	}
+	return entry
+}
+
+// Winner returns the stats of the winning player, or false when the match was not won.
+func (e Entry) Winner() (PlayerStats, bool) {
+	switch {
+	case e.WinnerID == "":
+		return PlayerStats{}, false
+	case e.WinnerID == e.PlayerA.ID:
+		return e.PlayerA, true
+	case e.WinnerID == e.PlayerB.ID:
+		return e.PlayerB, true
+	default:
+		return PlayerStats{}, false
+	}
+}
+
+// Log is a match history stored as a JSON Lines file: one Entry per line, appended as matches complete.
+type Log struct {
+	path string
+}
+
+// Open returns the match history stored at the given path. The file is created on the first Append.
+//
+// Parameters:
+//   - path: The path of the history file.
+//
+// Returns:
+//   - *Log: A pointer to the match history.
+//
+// Example:
+//   log := Open("history.jsonl")
+func Open(path string) *Log {
+	return &Log{path: path}
+}
+
+// Path returns the path of the history file.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append adds an entry to the end of the history.
+//
+// Parameters:
+//   - entry: The entry to add.
+//
+// Returns:
+//   - error: An error if the entry cannot be written.
+//
+// Example:
+//   err := log.Append(NewEntry(currentMatch))
+func (l *Log) Append(entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("history: failed to encode entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("history: failed to create %s: %w", filepath.Dir(l.path), err)
+	}
+	file, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("history: failed to open %s: %w", l.path, err)
+	}
+	//writing the whole line at once so concurrent appends do not interleave
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("history: failed to write %s: %w", l.path, err)
+	}
+	return file.Close()
+}
+
+// Filter selects the entries returned by Read.
+type Filter func(Entry) bool
+
+// ByPlayer selects the matches a player took part in, matched by ID or by name.
+func ByPlayer(idOrName string) Filter {
+	return func(e Entry) bool {
+		return e.PlayerA.ID == idOrName || e.PlayerB.ID == idOrName || e.PlayerA.Name == idOrName || e.PlayerB.Name == idOrName
+	}
+}
+
+// ByOutcome selects the matches that ended with the given outcome.
+func ByOutcome(outcome match.Outcome) Filter {
+	return func(e Entry) bool {
+		return e.Outcome == outcome
+	}
+}
+
+// Since selects the matches recorded at or after the given time.
+func Since(t time.Time) Filter {
+	return func(e Entry) bool {
+		return !e.Time.Before(t)
+	}
+}
+
+// Until selects the matches recorded before the given time.
+func Until(t time.Time) Filter {
+	return func(e Entry) bool {
+		return e.Time.Before(t)
+	}
+}
+
+// Read returns the entries of the history that pass every filter, oldest first. A missing file is an empty history.
+//
+// Parameters:
+//   - filters: The filters an entry must pass to be returned.
+//
+// Returns:
+//   - []Entry: The matching entries.
+//   - error: An error if the file cannot be read or an entry cannot be parsed.
+//
+// Example:
+//   wins, err := log.Read(ByPlayer("Hero"), ByOutcome(match.Win))
+func (l *Log) Read(filters ...Filter) ([]Entry, error) {
+	file, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("history: failed to open %s: %w", l.path, err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	decoder := json.NewDecoder(file)
+	for line := 1; ; line++ {
+		var entry Entry
+		err := decoder.Decode(&entry)
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return entries, fmt.Errorf("history: failed to parse entry %d of %s: %w", line, l.path, err)
+		}
+		if matches(entry, filters) {
+			entries = append(entries, entry)
+		}
+	}
+}
+
+// matches reports whether an entry passes every filter.
+func matches(entry Entry, filters []Filter) bool {
+	for _, filter := range filters {
+		if !filter(entry) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package history
+
+import (
+	"fmt"
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ANSI escape codes for text color
+const (
+	redColor   = "\033[31m"
+	greenColor = "\033[32m"
+	resetColor = "\033[0m"
+)
+
+// TestLog tests appending matches to the history and reading them back with filters.
+func TestLog(t *testing.T) {
+	log := Open(filepath.Join(t.TempDir(), "arena", "history.jsonl"))
+	hero := player.NewPlayer("Hero", 100, 5, 10)
+	brute := player.NewPlayer("Brute", 100, 5, 10)
+	mage := player.NewPlayer("Mage", 100, 5, 10)
+
+	// TEST 1: an empty history reads as no entries
+	if entries, err := log.Read(); err != nil || len(entries) != 0 {
+		t.Fatalf(redColor+"Expected an empty history, got %v, %v"+resetColor, entries, err)
+	}
+	fmt.Println(greenColor + "TestLog : Test1 : Passed" + resetColor)
+
+	// TEST 2: an entry round-trips with its stats, seed, rounds and outcome
+	won := match.NewMatch(hero, brute, match.WithSeed(7))
+	match.ConductMatch(won)
+	drawn := match.NewMatch(brute, mage, match.WithMaxRounds(2))
+	match.ConductMatch(drawn)
+	first := NewEntry(won)
+	if err := log.Append(first); err != nil {
+		t.Fatalf(redColor+"Unexpected error %v"+resetColor, err)
+	}
+	if err := log.Append(NewEntry(drawn)); err != nil {
+		t.Fatalf(redColor+"Unexpected error %v"+resetColor, err)
+	}
+	entries, err := log.Read()
+	if err != nil || len(entries) != 2 {
+		t.Fatalf(redColor+"Expected 2 entries, got %d, %v"+resetColor, len(entries), err)
+	}
+	winner, ok := entries[0].Winner()
+	if !entries[0].Time.Equal(first.Time) || entries[0].PlayerA != first.PlayerA || entries[0].Seed != 7 ||
+		!reflect.DeepEqual(entries[0].Rounds, won.RoundEvents()) || entries[0].Outcome != match.Win ||
+		!ok || winner.ID != player.GetPlayerID(won.Result().Winner) {
+		t.Errorf(redColor+"Unexpected entry %+v"+resetColor, entries[0])
+	} else {
+		fmt.Println(greenColor + "TestLog : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: filters select by player, outcome and time
+	byName, _ := log.Read(ByPlayer("Mage"))
+	byID, _ := log.Read(ByPlayer(player.GetPlayerID(hero)))
+	draws, _ := log.Read(ByOutcome(match.Draw), ByPlayer("Brute"))
+	future, _ := log.Read(Since(time.Now().Add(time.Hour)))
+	past, _ := log.Read(Until(time.Now().Add(time.Hour)))
+	if len(byName) != 1 || len(byID) != 1 || len(draws) != 1 || draws[0].Outcome != match.Draw || len(future) != 0 || len(past) != 2 {
+		t.Errorf(redColor+"Unexpected filter results %d %d %d %d %d"+resetColor, len(byName), len(byID), len(draws), len(future), len(past))
+	} else {
+		fmt.Println(greenColor + "TestLog : Test3 : Passed" + resetColor)
+	}
+}
+
+// TestMain runs the main testing suite.
+func TestMain(m *testing.M) {
+	fmt.Println("Testing history package...")
+	Result := m.Run()
+	fmt.Println("Testing complete.")
+	os.Exit(Result)
+}
@@ -0,0 +1,81 @@
+package match
+
+import (
+	"math/rand"
+	"time"
+)
+
+// diceFaces is the number of faces on the die rolled for every attack and defence.
+const diceFaces = 6
+
+// Dice is the source of die rolls used to resolve the rounds of a match.
+// Supplying a Dice to NewMatch (see WithDice) makes the outcome of a match fully controllable.
+type Dice interface {
+	// Roll returns the result of rolling a die with the given number of faces, in the range [1, faces].
+	Roll(faces int) int
+}
+
+// seededDice is the default Dice implementation, backed by its own math/rand source.
+type seededDice struct {
+	rng *rand.Rand
+}
+
+// NewSeededDice creates a Dice backed by a private pseudo-random source initialised with the given seed.
+// Two dice created with the same seed produce the same sequence of rolls.
+//
+// Parameters:
+//   - seed: The seed for the pseudo-random source.
+//
+// Returns:
+//   - Dice: A Dice producing a reproducible sequence of rolls.
+//
+// Example:
+//   dice := NewSeededDice(42)
+//   fmt.Println(dice.Roll(6))
+func NewSeededDice(seed int64) Dice {
+	return &seededDice{rng: rand.New(rand.NewSource(seed))}
+}
+
+// Roll returns a pseudo-random value in the range [1, faces].
+func (d *seededDice) Roll(faces int) int {
+	return d.rng.Intn(faces) + 1
+}
+
+// ScriptedDice is a Dice that returns a fixed sequence of rolls, starting over once the sequence is exhausted.
+// It is intended for tests and for reproducing a known sequence of rounds. A ScriptedDice without rolls always rolls 1.
+type ScriptedDice struct {
+	rolls []int
+	next  int
+}
+
+// NewScriptedDice creates a ScriptedDice that returns the given rolls in order.
+//
+// Parameters:
+//   - rolls: The rolls to return, in order. With no rolls, every roll is 1.
+//
+// Returns:
+//   - *ScriptedDice: A pointer to the newly created ScriptedDice instance.
+//
+// Example:
+//   dice := NewScriptedDice(6, 1)
+//   dice.Roll(6) // 6
+//   dice.Roll(6) // 1
+//   dice.Roll(6) // 6
+func NewScriptedDice(rolls ...int) *ScriptedDice {
+	return &ScriptedDice{rolls: rolls}
+}
+
+// Roll returns the next scripted roll, ignoring the number of faces, or 1 if there are no scripted rolls.
+func (d *ScriptedDice) Roll(faces int) int {
+	if len(d.rolls) == 0 {
+		return 1
+	}
+	roll := d.rolls[d.next]
+	d.next = (d.next + 1) % len(d.rolls)
+	return roll
+}
+
+// newSeed returns a seed for matches that were not given one explicitly.
+func newSeed() int64 {
+	return time.Now().UnixNano()
+}
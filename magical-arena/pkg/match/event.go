@@ -0,0 +1,76 @@
+package match
+
+import "fmt"
+
+// RoundEvent describes everything that happened in a single round of a match:
+// who attacked whom, the dice rolled, the resulting attack, defence and damage,
+// and the health of both players once the damage was applied.
+type RoundEvent struct {
+	// Round is the 1-based number of the round within the match.
+	Round int `json:"round"`
+
+	// AttackerID is the unique ID of the attacking player.
+	AttackerID string `json:"attackerId"`
+
+	// Attacker is the name of the attacking player.
+	Attacker string `json:"attacker"`
+
+	// DefenderID is the unique ID of the defending player.
+	DefenderID string `json:"defenderId"`
+
+	// Defender is the name of the defending player.
+	Defender string `json:"defender"`
+
+	// AttackRoll is the die rolled for the attacker.
+	AttackRoll int `json:"attackRoll"`
+
+	// DefenceRoll is the die rolled for the defender.
+	DefenceRoll int `json:"defenceRoll"`
+
+	// Attack is the attack value of the round (attacker attack * AttackRoll).
+	Attack int `json:"attack"`
+
+	// Defence is the defence value of the round (defender strength * DefenceRoll).
+	Defence int `json:"defence"`
+
+	// Damage is the damage dealt to the defender (Attack - Defence, never below zero).
+	Damage int `json:"damage"`
+
+	// AttackerHealth is the attacker's health after the round.
+	AttackerHealth int `json:"attackerHealth"`
+
+	// DefenderHealth is the defender's health after the round.
+	DefenderHealth int `json:"defenderHealth"`
+}
+
+// String returns the text form of the round, e.g. "PlayerA attacked PlayerB for 20 damage".
+//
+// Returns:
+//   - string: A description of the round result.
+//
+// Example:
+//   fmt.Println(event.String())
+func (e RoundEvent) String() string {
+	return fmt.Sprintf("%s attacked %s for %d damage", e.Attacker, e.Defender, e.Damage)
+}
+
+// RoundEventStrings converts a list of round events into their text form.
+//
+// Parameters:
+//   - events: The round events to convert.
+//
+// Returns:
+//   - []string: The text form of every event, in order.
+//
+// Example:
+//   events, _ := ConductMatch(myMatch)
+//   for _, line := range RoundEventStrings(events) {
+//       fmt.Println(line)
+//   }
+func RoundEventStrings(events []RoundEvent) []string {
+	lines := make([]string, len(events))
+	for i, event := range events {
+		lines[i] = event.String()
+	}
+	return lines
+}
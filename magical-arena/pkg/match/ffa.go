@@ -0,0 +1,229 @@
+package match
+
+import (
+	"errors"
+	"magical-arena/pkg/player"
+)
+
+// ErrTooFewPlayers is returned when a match is created with fewer than two participants.
+var ErrTooFewPlayers = errors.New("a match needs at least two participants")
+
+// ErrNoTargetingPolicy is returned when a match with more than two players is created without a TargetingPolicy.
+var ErrNoTargetingPolicy = errors.New("a match needs a targeting policy")
+
+// ErrInvalidTarget is returned when a TargetingPolicy chooses a target that is not one of the candidates.
+var ErrInvalidTarget = errors.New("the targeting policy chose a target that is not a candidate")
+
+// FreeForAll is a match between any number of players. Turns rotate through every player still alive,
+// each attacker hits a target chosen by the match's TargetingPolicy, and the last player standing wins.
+type FreeForAll struct {
+	// fighters holds the live state of every player, in turn order.
+	fighters []*Fighter
+
+	// policy chooses the target of every attack.
+	policy TargetingPolicy
+
+	// next is the index in fighters of the player who attacks in the next round.
+	next int
+
+	// roundEvents stores the events of each round in the match.
+	roundEvents []RoundEvent
+
+	// result indicates the overall result of the match.
+	result Result
+
+	// settings holds the dice, seed and round limits of the match.
+	settings
+
+	// round is the number of rounds played so far.
+	round int
+
+	// zeroDamageRounds is the number of consecutive rounds in which no damage was dealt.
+	zeroDamageRounds int
+}
+
+// NewFreeForAll creates a free-for-all match between the given players.
+// Turns follow the order of players, starting with the player with the lowest health (the earliest one on ties),
+// in line with the two-player rule that the player with lower health attacks first.
+//
+// Parameters:
+//   - players: The players in the match, in turn order.
+//   - policy: The TargetingPolicy choosing whom each attacker hits, such as RandomTarget or WeakestTarget.
+//   - opts: Optional settings such as WithSeed, WithDice, WithMaxRounds, WithStalemateRounds or WithRules.
+//     The starting player policy of the rules does not apply.
+//
+// Returns:
+//   - *FreeForAll: A pointer to the newly created match.
+//   - error: ErrTooFewPlayers if fewer than two players were given, ErrNoTargetingPolicy if policy is nil.
+//
+// Example:
+//   ffa, err := NewFreeForAll([]*player.Player{player1, player2, player3}, WeakestTarget, WithSeed(42))
+func NewFreeForAll(players []*player.Player, policy TargetingPolicy, opts ...Option) (*FreeForAll, error) {
+	if len(players) < 2 {
+		return nil, ErrTooFewPlayers
+	}
+	if policy == nil {
+		return nil, ErrNoTargetingPolicy
+	}
+
+	ffa := &FreeForAll{policy: policy, roundEvents: []RoundEvent{}, settings: newSettings(opts)}
+	for _, p := range players {
+		_, health, _, _ := player.GetPlayerBaseAttributes(p)
+		ffa.fighters = append(ffa.fighters, &Fighter{Player: p, Health: health})
+	}
+
+	//the player alive with the lowest health attacks first
+	ffa.next = -1
+	for i, fighter := range ffa.fighters {
+		if fighter.isAlive() && (ffa.next < 0 || fighter.Health < ffa.fighters[ffa.next].Health) {
+			ffa.next = i
+		}
+	}
+
+	//players who enter with no health are out before the first round
+	ffa.checkLastStanding()
+	return ffa, nil
+}
+
+// Seed returns the seed the match dice were created from.
+func (ffa *FreeForAll) Seed() int64 {
+	return ffa.seed
+}
+
+// IsOver reports whether the match has finished.
+func (ffa *FreeForAll) IsOver() bool {
+	return ffa.result.Outcome != Undecided
+}
+
+// Result returns the result of the match once it is over, with Outcome Undecided before that.
+func (ffa *FreeForAll) Result() Result {
+	return ffa.result
+}
+
+// RoundEvents returns the events of the rounds played so far.
+func (ffa *FreeForAll) RoundEvents() []RoundEvent {
+	return ffa.roundEvents
+}
+
+// Fighters returns a snapshot of every player's live state, in turn order.
+//
+// Returns:
+//   - []Fighter: The player, current health and last attacker of every participant.
+//
+// Example:
+//   for _, fighter := range ffa.Fighters() {
+//       fmt.Println(fighter.Health)
+//   }
+func (ffa *FreeForAll) Fighters() []Fighter {
+	fighters := make([]Fighter, len(ffa.fighters))
+	for i, fighter := range ffa.fighters {
+		fighters[i] = *fighter
+	}
+	return fighters
+}
+
+// NextRound plays a single round: the next player alive attacks the target chosen by the TargetingPolicy,
+// and the turn passes to the next player alive. If the policy chooses a target that is not one of the candidates,
+// no round is played and the match is aborted.
+//
+// Returns:
+//   - RoundEvent: The event describing the round that was played.
+//   - error: ErrMatchOver if the match had already finished, ErrInvalidTarget if the policy chose an invalid target,
+//     nil otherwise.
+//
+// Example:
+//   for !ffa.IsOver() {
+//       event, _ := ffa.NextRound()
+//       fmt.Println(event)
+//   }
+func (ffa *FreeForAll) NextRound() (RoundEvent, error) {
+	if ffa.IsOver() {
+		return RoundEvent{}, ErrMatchOver
+	}
+
+	//choosing a target among the opponents still alive
+	attacker := ffa.fighters[ffa.next]
+	candidates := make([]*Fighter, 0, len(ffa.fighters)-1)
+	for _, fighter := range ffa.fighters {
+		if fighter != attacker && fighter.isAlive() {
+			candidates = append(candidates, fighter)
+		}
+	}
+	target, ok := chooseTarget(ffa.policy, ffa.dice, attacker, candidates)
+	if !ok {
+		ffa.result = Result{Outcome: Aborted, Rounds: ffa.round}
+		return RoundEvent{}, ErrInvalidTarget
+	}
+
+	//conducting the round
+	ffa.round++
+	roundEvent := strike(ffa.dice, ffa.rules, ffa.round, attacker, target)
+	ffa.roundEvents = append(ffa.roundEvents, roundEvent)
+	ffa.advance()
+
+	//counting consecutive rounds without damage for the stalemate rule
+	if roundEvent.Damage == 0 {
+		ffa.zeroDamageRounds++
+	} else {
+		ffa.zeroDamageRounds = 0
+	}
+
+	if !ffa.checkLastStanding() {
+		if outcome := ffa.limitOutcome(ffa.round, ffa.zeroDamageRounds); outcome != Undecided {
+			ffa.result = Result{Outcome: outcome, Rounds: ffa.round}
+		}
+	}
+	return roundEvent, nil
+}
+
+// advance passes the turn to the next player alive after the current one.
+func (ffa *FreeForAll) advance() {
+	for i := 1; i <= len(ffa.fighters); i++ {
+		next := (ffa.next + i) % len(ffa.fighters)
+		if ffa.fighters[next].isAlive() {
+			ffa.next = next
+			return
+		}
+	}
+}
+
+// checkLastStanding records the result of the match once at most one player is alive.
+//
+// Returns:
+//   - bool: true if the match is over, false otherwise.
+func (ffa *FreeForAll) checkLastStanding() bool {
+	var survivor *Fighter
+	for _, fighter := range ffa.fighters {
+		if fighter.isAlive() {
+			if survivor != nil {
+				return false
+			}
+			survivor = fighter
+		}
+	}
+	if survivor == nil {
+		ffa.result = Result{Outcome: Draw, Rounds: ffa.round}
+	} else {
+		ffa.result = Result{Outcome: Win, Winner: survivor.Player, Rounds: ffa.round}
+	}
+	return true
+}
+
+// ConductFreeForAll plays a free-for-all match until one player remains, a round limit ends it or it is aborted.
+//
+// Parameters:
+//   - ffa: A pointer to the FreeForAll match to play.
+//
+// Returns:
+//   - []RoundEvent: A slice containing the event of each round.
+//   - Result: The result of the match.
+//
+// Example:
+//   roundEvents, result := ConductFreeForAll(ffa)
+//   fmt.Println("Match Result:", result)
+func ConductFreeForAll(ffa *FreeForAll) ([]RoundEvent, Result) {
+	for !ffa.IsOver() {
+		ffa.NextRound()
+	}
+	return ffa.roundEvents, ffa.result
+}
@@ -0,0 +1,116 @@
+package match
+
+import (
+	"fmt"
+	"magical-arena/pkg/player"
+	"reflect"
+	"testing"
+)
+
+// TestFreeForAll tests free-for-all matches between more than two players.
+func TestFreeForAll(t *testing.T) {
+	// TEST 1: fewer than two players is rejected
+	_, err := NewFreeForAll([]*player.Player{player.NewPlayer("Solo", 10, 1, 1)}, RandomTarget)
+	if err != ErrTooFewPlayers {
+		t.Errorf(redColor+"Expected ErrTooFewPlayers, got %v"+resetColor, err)
+	} else {
+		fmt.Println(greenColor + "TestFreeForAll : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: the lowest-health player starts, turns rotate, and WeakestTarget focuses the weakest opponent.
+	// Every die rolls 4, so each hit deals 4*10 - 4*5 = 20 damage.
+	playerA := player.NewPlayer("PlayerA", 100, 5, 10)
+	playerB := player.NewPlayer("PlayerB", 30, 5, 10)
+	playerC := player.NewPlayer("PlayerC", 60, 5, 10)
+	ffa, _ := NewFreeForAll([]*player.Player{playerA, playerB, playerC}, WeakestTarget, WithDice(NewScriptedDice(4)))
+	first, _ := ffa.NextRound()
+	second, _ := ffa.NextRound()
+	if first.Attacker != "PlayerB" || first.Defender != "PlayerC" || second.Attacker != "PlayerC" || second.Defender != "PlayerB" {
+		t.Errorf(redColor+"Unexpected opening rounds %s / %s"+resetColor, first, second)
+	} else {
+		fmt.Println(greenColor + "TestFreeForAll : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: the match ends with a single survivor
+	events, result := ConductFreeForAll(ffa)
+	alive := 0
+	for _, fighter := range ffa.Fighters() {
+		if fighter.Health > 0 {
+			alive++
+		}
+	}
+	if result.Outcome != Win || alive != 1 || result.Rounds != len(events) {
+		t.Errorf(redColor+"Expected a single winner, got %s with %d alive"+resetColor, result, alive)
+	} else {
+		fmt.Println(greenColor + "TestFreeForAll : Test3 : Passed" + resetColor)
+	}
+
+	// TEST 4: LastAttackerTarget strikes back at the player who last attacked
+	playerA = player.NewPlayer("PlayerA", 100, 5, 10)
+	playerB = player.NewPlayer("PlayerB", 100, 5, 10)
+	playerC = player.NewPlayer("PlayerC", 90, 5, 10)
+	ffa, _ = NewFreeForAll([]*player.Player{playerA, playerB, playerC}, LastAttackerTarget, WithDice(NewScriptedDice(4)))
+	ffa.fighters[0].LastAttacker = playerB
+	ffa.next = 0
+	event, _ := ffa.NextRound()
+	if event.Attacker != "PlayerA" || event.Defender != "PlayerB" {
+		t.Errorf(redColor+"Expected PlayerA to strike back at PlayerB, got %s"+resetColor, event)
+	} else {
+		fmt.Println(greenColor + "TestFreeForAll : Test4 : Passed" + resetColor)
+	}
+
+	// TEST 5: seeded random targeting is reproducible
+	players := []*player.Player{playerA, playerB, playerC, player.NewPlayer("PlayerD", 80, 5, 10)}
+	firstFFA, _ := NewFreeForAll(players, RandomTarget, WithSeed(9))
+	secondFFA, _ := NewFreeForAll(players, RandomTarget, WithSeed(9))
+	firstEvents, firstResult := ConductFreeForAll(firstFFA)
+	secondEvents, secondResult := ConductFreeForAll(secondFFA)
+	if fmt.Sprint(firstEvents) != fmt.Sprint(secondEvents) || firstResult != secondResult {
+		t.Errorf(redColor+"Expected identical seeded matches, got %s and %s"+resetColor, firstResult, secondResult)
+	} else {
+		fmt.Println(greenColor + "TestFreeForAll : Test5 : Passed" + resetColor)
+	}
+
+	// TEST 6: random targeting wraps scripted rolls outside the range of candidates instead of panicking
+	candidates := []*Fighter{{Player: playerA}, {Player: playerB}, {Player: playerC}}
+	zero := randomTarget(NewScriptedDice(0), nil, candidates)
+	negative := randomTarget(NewScriptedDice(-4), nil, candidates)
+	large := randomTarget(NewScriptedDice(5), nil, candidates)
+	if zero != candidates[2] || negative != candidates[1] || large != candidates[1] {
+		t.Errorf(redColor+"Unexpected targets %v, %v, %v"+resetColor, zero.Player, negative.Player, large.Player)
+	} else {
+		fmt.Println(greenColor + "TestFreeForAll : Test6 : Passed" + resetColor)
+	}
+
+	// TEST 7: a nil policy is rejected, and a policy choosing an invalid target aborts the match instead of panicking
+	_, nilErr := NewFreeForAll(players, nil)
+	none := TargetingPolicyFunc(func(dice Dice, attacker *Fighter, candidates []*Fighter) *Fighter {
+		return nil
+	})
+	ffa, _ = NewFreeForAll(players, none)
+	events, result = ConductFreeForAll(ffa)
+	if nilErr != ErrNoTargetingPolicy || result.Outcome != Aborted || len(events) != 0 {
+		t.Errorf(redColor+"Expected ErrNoTargetingPolicy and an aborted match, got %v, %s"+resetColor, nilErr, result)
+	} else {
+		fmt.Println(greenColor + "TestFreeForAll : Test7 : Passed" + resetColor)
+	}
+
+	// TEST 8: a policy changing the fighters it is given does not change the match
+	meddling := TargetingPolicyFunc(func(dice Dice, attacker *Fighter, candidates []*Fighter) *Fighter {
+		target := weakestTarget(dice, attacker, candidates)
+		for _, candidate := range candidates {
+			candidate.Health, candidate.LastAttacker = 0, attacker.Player
+		}
+		attacker.Health = 1000
+		return target
+	})
+	ffa, _ = NewFreeForAll([]*player.Player{playerA, playerB, playerC}, WeakestTarget, WithDice(NewScriptedDice(4)))
+	wantEvents, wantResult := ConductFreeForAll(ffa)
+	ffa, _ = NewFreeForAll([]*player.Player{playerA, playerB, playerC}, meddling, WithDice(NewScriptedDice(4)))
+	events, result = ConductFreeForAll(ffa)
+	if !reflect.DeepEqual(events, wantEvents) || result.Outcome != wantResult.Outcome || result.Winner != wantResult.Winner {
+		t.Errorf(redColor+"Expected %s after %+v, got %s after %+v"+resetColor, wantResult, wantEvents, result, events)
+	} else {
+		fmt.Println(greenColor + "TestFreeForAll : Test8 : Passed" + resetColor)
+	}
+}
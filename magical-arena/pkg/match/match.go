@@ -2,11 +2,17 @@ package match
 
 // Import the player package to use the Player struct.
 import (
-	"fmt"
+	"context"
+	"errors"
 	"magical-arena/pkg/player"
-	"math/rand"
 )
 
+// ErrMatchOver is returned by NextRound when the match has already finished.
+var ErrMatchOver = errors.New("match is already over")
+
+// ErrSamePlayer is returned by NextRound for a match between a player and themselves, which cannot be played.
+var ErrSamePlayer = errors.New("a player cannot fight themselves")
+
 // Match represents a match between two players in the Magical Arena.
 type Match struct {
 	// PlayerA is a pointer to the first player in the match.
@@ -15,73 +21,289 @@ type Match struct {
 	// PlayerB is a pointer to the second player in the match.
 	PlayerB *player.Player
 
-	// RoundResults stores the results of each round in the match.
-	roundResults []string
+	// roundEvents stores the events of each round in the match.
+	roundEvents []RoundEvent
+
+	// result indicates the overall result of the match (e.g., "PlayerA wins", "Draw", etc.).
+	result Result
+
+	// settings holds the dice, seed and round limits of the match.
+	settings
+
+	// currentPlayer is the player who attacks in the next round.
+	currentPlayer *player.Player
+
+	// healthA is the current health of PlayerA.
+	healthA int
+
+	// healthB is the current health of PlayerB.
+	healthB int
+
+	// round is the number of rounds played so far.
+	round int
+
+	// zeroDamageRounds is the number of consecutive rounds in which no damage was dealt.
+	zeroDamageRounds int
+}
+
+// MatchState is a snapshot of a match in progress.
+type MatchState struct {
+	// Round is the number of rounds played so far.
+	Round int
+
+	// Attacker is the player who attacks in the next round.
+	Attacker *player.Player
+
+	// HealthA is the current health of PlayerA.
+	HealthA int
+
+	// HealthB is the current health of PlayerB.
+	HealthB int
 
-	// Result indicates the overall result of the match (e.g., "PlayerA wins", "Draw", etc.).
-	result string
+	// Over reports whether the match has finished.
+	Over bool
+
+	// Result is the result of the match once it is over, with Outcome Undecided before that.
+	Result Result
 }
 
 // NewMatch creates and initializes a new Match instance with the provided players.
+// The match starts with both players at full health and the starting player chosen by the rules
+// (the player with lower health by default), unless WithFirstAttacker says otherwise.
+// By default there is no round limit, and DefaultStalemateRounds consecutive zero-damage rounds end the match in a Stalemate.
+// Unless a Dice or seed is supplied through the options, the match rolls dice seeded from the current time;
+// the seed in use is available through Seed.
+//
+// A player cannot fight themselves: when both players are the same player, or copies with the same ID, the match
+// is created already over with an Aborted result after 0 rounds, and NextRound returns ErrSamePlayer.
+// Rules.ValidatePlayers reports this case before a match is created.
 //
 // Parameters:
 //   - playerA: A pointer to the first player in the match.
 //   - playerB: A pointer to the second player in the match.
+//   - opts: Optional settings such as WithSeed, WithDice, WithMaxRounds, WithStalemateRounds, WithFirstAttacker or WithRules.
 //
 // Returns:
 //   - *Match: A pointer to the newly created Match instance.
 //
 // Example:
 //   match := NewMatch(player1, player2)
-func NewMatch(playerA, playerB *player.Player) *Match {
-	return &Match{playerA, playerB, []string{}, ""}
+//   seeded := NewMatch(player1, player2, WithSeed(42))
+func NewMatch(playerA, playerB *player.Player, opts ...Option) *Match {
+	m := &Match{PlayerA: playerA, PlayerB: playerB, roundEvents: []RoundEvent{}, settings: newSettings(opts)}
+
+	// The starting player policy of the rules decides who attacks first, the player with lower health by default
+	if m.firstAttacker != nil && (m.firstAttacker == playerA || m.firstAttacker == playerB) {
+		m.currentPlayer = m.firstAttacker
+	} else {
+		m.currentPlayer = determineStartingPlayer(m)
+	}
+	_, m.healthA, _, _ = player.GetPlayerBaseAttributes(playerA)
+	_, m.healthB, _, _ = player.GetPlayerBaseAttributes(playerB)
+
+	//a player cannot fight themselves, and a player who enters with no health has already lost
+	if samePlayer(playerA, playerB) {
+		m.result = Result{Outcome: Aborted}
+	} else if isMatchOver(m.healthA, m.healthB) {
+		m.result = MatchResult(playerA, m.healthA, playerB, m.healthB, 0)
+	}
+	return m
+}
+
+// Seed returns the seed the match dice were created from. It is only meaningful
+// when the match was not given its own Dice through WithDice.
+//
+// Returns:
+//   - int64: The seed of the match dice.
+//
+// Example:
+//   replay := NewMatch(player1, player2, WithSeed(match.Seed()))
+func (m *Match) Seed() int64 {
+	return m.seed
+}
+
+// RoundEvents returns the events of the rounds played so far.
+//
+// Returns:
+//   - []RoundEvent: The event of every round played, in order.
+//
+// Example:
+//   for _, event := range myMatch.RoundEvents() {
+//       fmt.Println(event.Round, event.Damage)
+//   }
+func (m *Match) RoundEvents() []RoundEvent {
+	return m.roundEvents
+}
+
+// RoundResults returns the text form of the rounds played so far.
+//
+// Returns:
+//   - []string: A description of every round played, in order.
+//
+// Example:
+//   fmt.Println(strings.Join(myMatch.RoundResults(), "\n"))
+func (m *Match) RoundResults() []string {
+	return RoundEventStrings(m.roundEvents)
+}
+
+// IsOver reports whether the match has finished, whether by a win, a draw, a stalemate or an abort.
+//
+// Returns:
+//   - bool: true if the match is over, false otherwise.
+//
+// Example:
+//   for !myMatch.IsOver() {
+//       myMatch.NextRound()
+//   }
+func (m *Match) IsOver() bool {
+	return m.result.Outcome != Undecided
+}
+
+// State returns a snapshot of the match: the rounds played, who attacks next and the current health of both players.
+//
+// Returns:
+//   - MatchState: The current state of the match.
+//
+// Example:
+//   state := myMatch.State()
+//   fmt.Printf("Round %d: %d vs %d\n", state.Round, state.HealthA, state.HealthB)
+func (m *Match) State() MatchState {
+	return MatchState{
+		Round:    m.round,
+		Attacker: m.currentPlayer,
+		HealthA:  m.healthA,
+		HealthB:  m.healthB,
+		Over:     m.IsOver(),
+		Result:   m.result,
+	}
+}
+
+// Result returns the result of the match once it is over, with Outcome Undecided before that.
+//
+// Returns:
+//   - Result: The result of the match.
+//
+// Example:
+//   fmt.Println("Match Result:", myMatch.Result())
+func (m *Match) Result() Result {
+	return m.result
+}
+
+// NextRound plays a single round of the match: the current player attacks the other player,
+// the round event is recorded, and the turn passes to the other player.
+// When the round ends the match (a player falls, the round limit is reached or the stalemate rule applies),
+// the match result is recorded as well.
+//
+// Returns:
+//   - RoundEvent: The event describing the round that was played.
+//   - error: ErrSamePlayer if both players are the same player, ErrMatchOver if the match had already finished,
+//     nil otherwise.
+//
+// Example:
+//   event, err := myMatch.NextRound()
+//   if err == nil {
+//       fmt.Println(event)
+//   }
+func (m *Match) NextRound() (RoundEvent, error) {
+	if samePlayer(m.PlayerA, m.PlayerB) {
+		return RoundEvent{}, ErrSamePlayer
+	}
+	if m.IsOver() {
+		return RoundEvent{}, ErrMatchOver
+	}
+
+	//conducting a round
+	m.round++
+	roundEvent, healthA, healthB := conductRound(m.dice, m.rules, m.round, m.currentPlayer, m.PlayerA, m.healthA, m.PlayerB, m.healthB)
+	m.roundEvents = append(m.roundEvents, roundEvent)
+	m.healthA, m.healthB = healthA, healthB
+
+	//switching the current player (example: if current player is playerA, switch to playerB)
+	switchCurrentPlayer(&m.currentPlayer, m.PlayerA, m.PlayerB)
+
+	//counting consecutive rounds without damage for the stalemate rule
+	if roundEvent.Damage == 0 {
+		m.zeroDamageRounds++
+	} else {
+		m.zeroDamageRounds = 0
+	}
+
+	if isMatchOver(m.healthA, m.healthB) {
+		m.result = MatchResult(m.PlayerA, m.healthA, m.PlayerB, m.healthB, m.round)
+	} else if outcome := m.limitOutcome(m.round, m.zeroDamageRounds); outcome != Undecided {
+		m.result = Result{Outcome: outcome, Rounds: m.round}
+	}
+	return roundEvent, nil
 }
 
 // ConductMatch simulates a match between two players in the magical arena.
-// The player with lower health attacks first, and rounds are conducted until the match is over (player.health <= 0).
-// The result of each round and the overall match result are recorded.
+// The player with lower health attacks first, and rounds are conducted with NextRound until the match is over
+// (player.health <= 0, the round limit is reached or the stalemate rule applies).
+// The event of each round and the overall match result are recorded.
 //
 // Parameters:
 //   - match: A pointer to the Match instance representing the ongoing match (type *Match).
 //
 // Returns:
-//   - []string: A slice containing descriptions of each round result.
-//   - string: A string indicating the result of the entire match.
+//   - []RoundEvent: A slice containing the event of each round.
+//   - Result: The result of the entire match.
 //
 // Example:
-//   roundResults, matchResult := ConductMatch(myMatch)
-//   fmt.Println("Round Results:", roundResults)
+//   roundEvents, matchResult := ConductMatch(myMatch)
+//   fmt.Println("Round Results:", RoundEventStrings(roundEvents))
 //   fmt.Println("Match Result:", matchResult)
 //
-// Note: This function updates the Match instance with round results and the final match result.
-func ConductMatch(match *Match) ([]string, string) {
-	// The player with lower health attacks first
-	// determine the starting player
-	currentPlayer := determineStartingPlayer(match)
+// Note: This function updates the Match instance with round events and the final match result.
+// A match that was already partly played with NextRound is played to the end.
+func ConductMatch(match *Match) ([]RoundEvent, Result) {
+	for !match.IsOver() {
+		match.NextRound()
+	}
+	return match.roundEvents, match.result
+}
 
-	//initializing the match
-	//extracting the attributes of the players
-	nameA, healthA, strengthA, attackA := player.GetPlayerBaseAttributes(match.PlayerA)
-	nameB, healthB, strengthB, attackB := player.GetPlayerBaseAttributes(match.PlayerB)
-
-	//conducting the match
-	for !isMatchOver(healthA, healthB) {
-		//conducting a round
-		roundResult, currentHealthA, currentHealthB := conductRound(currentPlayer, nameA, healthA, strengthA, attackA, nameB, healthB, strengthB, attackB)
-		match.roundResults = append(match.roundResults, roundResult)
-		//updating the health of playerA
-		healthA = currentHealthA
-		//updating the health of playerB
-		healthB = currentHealthB
-		//switching the current player (example: if current player is playerA, switch to playerB)
-		switchCurrentPlayer(&currentPlayer, match.PlayerA, match.PlayerB)
+// ConductMatchContext works like ConductMatch but checks ctx before every round.
+// If ctx is cancelled or its deadline passes before the match is over, the match is aborted:
+// the rounds played so far are returned together with an Aborted result and ctx.Err().
+//
+// Parameters:
+//   - ctx: The context controlling cancellation and deadlines of the match.
+//   - match: A pointer to the Match instance representing the ongoing match (type *Match).
+//
+// Returns:
+//   - []RoundEvent: A slice containing the event of each round played.
+//   - Result: The result of the match, with Outcome Aborted if ctx ended first.
+//   - error: ctx.Err() if the match was aborted, nil otherwise.
+//
+// Example:
+//   ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//   defer cancel()
+//   roundEvents, matchResult, err := ConductMatchContext(ctx, myMatch)
+func ConductMatchContext(ctx context.Context, match *Match) ([]RoundEvent, Result, error) {
+	for !match.IsOver() {
+		if err := ctx.Err(); err != nil {
+			match.Abort()
+			return match.roundEvents, match.result, err
+		}
+		match.NextRound()
 	}
+	return match.roundEvents, match.result, nil
+}
 
-	match.result = MatchResult(nameA, healthA, nameB, healthB)
-	return match.roundResults, match.result
+// Abort stops a match that is still in progress, recording an Aborted result.
+// Aborting a match that is already over has no effect.
+//
+// Example:
+//   myMatch.Abort()
+//   fmt.Println(myMatch.Result()) // Output: "Match aborted after 3 rounds"
+func (m *Match) Abort() {
+	if !m.IsOver() {
+		m.result = Result{Outcome: Aborted, Rounds: m.round}
+	}
 }
 
-// determineStartingPlayer determines the starting player for a match based on their health attributes.
+// determineStartingPlayer determines the starting player for a match according to the starting player policy
+// of its rules. By default the player with lower health starts, Player A on a tie.
 //
 // Parameters:
 //   - match: A pointer to the Match instance representing the ongoing match.
@@ -93,13 +315,29 @@ func ConductMatch(match *Match) ([]string, string) {
 //   startingPlayer := determineStartingPlayer(myMatch)
 //   fmt.Printf("%s starts the match\n", startingPlayer.Name)
 //
-// Note: The function compares the health attributes of the players to determine the starting player.
+// Note: The StartRandom policy rolls the match dice, so the match must have its settings in place.
 func determineStartingPlayer(match *Match) *player.Player {
 	//extracting the attributes of the players
 	_, healthA, _, _ := player.GetPlayerBaseAttributes(match.PlayerA)
 	_, healthB, _, _ := player.GetPlayerBaseAttributes(match.PlayerB)
 
-	//determining the starting player based on health attributes
+	//determining the starting player based on the policy
+	switch match.rules.StartingPlayer {
+	case StartHigherHealth:
+		if healthA >= healthB {
+			return match.PlayerA
+		}
+		return match.PlayerB
+	case StartPlayerA:
+		return match.PlayerA
+	case StartRandom:
+		if match.dice.Roll(2) == 1 {
+			return match.PlayerA
+		}
+		return match.PlayerB
+	}
+
+	//the player with lower health starts by default
 	if healthA <= healthB {
 		return match.PlayerA
 	}
@@ -171,95 +409,92 @@ func GetIsMatchOver(healthA, healthB int) bool {
 }
 
 // conductRound simulates a single round of a match between two players.
-// It calculates the damage inflicted by the current player on the opponent based on random dice rolls,
-// considering the attack and defense attributes of both players.
+// The current player, identified by pointer rather than by name, attacks the other player.
 //
 // Parameters:
-//   - currentPlayer: A pointer to the current player (type *player.Player).
-//   - nameA: The name of Player A.
+//   - dice: The Dice used to roll attack and defence.
+//   - rules: The rules deciding the dice faces and the damage floor.
+//   - round: The 1-based number of the round.
+//   - currentPlayer: A pointer to the current player (type *player.Player), either playerA or playerB.
+//   - playerA: A pointer to Player A.
 //   - healthA: The current health of Player A.
-//   - strengthA: The strength attribute of Player A.
-//   - attackA: The attack attribute of Player A.
-//   - nameB: The name of Player B.
+//   - playerB: A pointer to Player B.
 //   - healthB: The current health of Player B.
-//   - strengthB: The strength attribute of Player B.
-//   - attackB: The attack attribute of Player B.
-//   - match: A pointer to the Match struct representing the ongoing match (type *Match).
 //
 // Returns:
-//   - string: A description of the round result.
+//   - RoundEvent: The event describing the round.
+//   - int: The health of Player A after the round.
+//   - int: The health of Player B after the round.
 //
 // Note: The function updates the health of the opponent player based on the calculated damage.
-func conductRound(currentPlayer *player.Player, nameA string, healthA int, strengthA int, attackA int, nameB string, healthB int, strengthB int, attackB int) (string, int, int) {
-	//fetching the base attributes of the current player
-	playerName, _, _, _ := player.GetPlayerBaseAttributes(currentPlayer)
-
-	//initializing the roundresult and the current health of the attacking and opponent player
-	roundResult := ""
-	currentHealthB := healthB
-	currentHealthA := healthA
-
+func conductRound(dice Dice, rules Rules, round int, currentPlayer *player.Player, playerA *player.Player, healthA int, playerB *player.Player, healthB int) (RoundEvent, int, int) {
 	//conducting the round
-	if playerName == nameA {
-		attackFromCurrentPlayer := attackA * (rand.Intn(6) + 1)
-		defenceFromOtherPlayer := strengthB * (rand.Intn(6) + 1)
-		damageToOtherPlayer := max(0, attackFromCurrentPlayer-defenceFromOtherPlayer)
-		currentHealthB = max(0, healthB-damageToOtherPlayer)
-		roundResult = fmt.Sprintf("%s attacked %s for %d damage", nameA, nameB, damageToOtherPlayer)
+	if currentPlayer == playerA {
+		roundEvent := attack(dice, rules, round, playerA, healthA, playerB, healthB)
+		return roundEvent, healthA, roundEvent.DefenderHealth
 	}
-
-	if playerName == nameB {
-		attackFromCurrentPlayer := attackB * (rand.Intn(6) + 1)
-		defenceFromOtherPlayer := strengthA * (rand.Intn(6) + 1)
-		damageToOtherPlayer := max(0, attackFromCurrentPlayer-defenceFromOtherPlayer)
-		currentHealthA = max(0, healthA-damageToOtherPlayer)
-		roundResult = fmt.Sprintf("%s attacked %s for %d damage", nameB, nameA, damageToOtherPlayer)
-	}
-
-	//below code is used for unit testing please ignore otherwise
-	//only for testing purposes current player is set to testA
-	if playerName == "testA" {
-		attackFromCurrentPlayer := attackA * 4
-		defenceFromOtherPlayer := strengthB * 4
-		damageToOtherPlayer := max(0, attackFromCurrentPlayer-defenceFromOtherPlayer)
-		currentHealthB = max(0, healthB-damageToOtherPlayer)
-		roundResult = fmt.Sprintf("%s attacked %s for %d damage", nameA, nameB, damageToOtherPlayer)
-	}
-
-	//only for testing purposes current player is set to testB
-	if playerName == "testB" {
-		attackFromCurrentPlayer := attackB * 4
-		defenceFromOtherPlayer := strengthA * 4
-		damageToOtherPlayer := max(0, attackFromCurrentPlayer-defenceFromOtherPlayer)
-		currentHealthA = max(0, healthA-damageToOtherPlayer)
-		roundResult = fmt.Sprintf("%s attacked %s for %d damage", nameB, nameA, damageToOtherPlayer)
-	}
-
-	//returning the round result and the updated health of the attacking and opponent player
-	return roundResult, currentHealthA, currentHealthB
+	roundEvent := attack(dice, rules, round, playerB, healthB, playerA, healthA)
+	return roundEvent, roundEvent.DefenderHealth, healthB
 }
 
 // GetConductRound is a wrapper function that exposes the conductRound functionality for Testing
-// of conducting a single round of a match between two players using conductRound.
+// of conducting a single round of a match between two players using conductRound under the default rules.
 //
 // Parameters:
-//   - currentPlayer: A pointer to the current player (type *player.Player).
-//   - nameA: The name of Player A.
+//   - dice: The Dice used to roll attack and defence.
+//   - round: The 1-based number of the round.
+//   - currentPlayer: A pointer to the current player (type *player.Player), either playerA or playerB.
+//   - playerA: A pointer to Player A.
 //   - healthA: The current health of Player A.
-//   - strengthA: The strength attribute of Player A.
-//   - attackA: The attack attribute of Player A.
-//   - nameB: The name of Player B.
+//   - playerB: A pointer to Player B.
 //   - healthB: The current health of Player B.
-//   - strengthB: The strength attribute of Player B.
-//   - attackB: The attack attribute of Player B.
-//   - match: A pointer to the Match struct representing the ongoing match (type *Match).
 //
 // Returns:
-//   - string: A description of the round result.
+//   - RoundEvent: The event describing the round.
+//   - int: The health of Player A after the round.
+//   - int: The health of Player B after the round.
 //
 // Note: This function servers as a testing wrapper for the private conductRound function.
-func GetConductRound(currentPlayer *player.Player, nameA string, healthA int, strengthA int, attackA int, nameB string, healthB int, strengthB int, attackB int) (string, int, int) {
-	return conductRound(currentPlayer, nameA, healthA, strengthA, attackA, nameB, healthB, strengthB, attackB)
+func GetConductRound(dice Dice, round int, currentPlayer *player.Player, playerA *player.Player, healthA int, playerB *player.Player, healthB int) (RoundEvent, int, int) {
+	return conductRound(dice, DefaultRules(), round, currentPlayer, playerA, healthA, playerB, healthB)
+}
+
+// attack resolves a single attack of one player on another.
+// It calculates the damage inflicted by the attacker based on dice rolls, considering the attack attribute
+// of the attacker and the strength attribute of the defender. The attack die is rolled before the defence die,
+// and the damage never goes below the damage floor of the rules.
+//
+// Parameters:
+//   - dice: The Dice used to roll attack and defence.
+//   - rules: The rules deciding the dice faces and the damage floor.
+//   - round: The 1-based number of the round.
+//   - attacker: A pointer to the attacking player.
+//   - attackerHealth: The current health of the attacking player.
+//   - defender: A pointer to the defending player.
+//   - defenderHealth: The current health of the defending player.
+//
+// Returns:
+//   - RoundEvent: The event describing the attack, including the defender's health after it.
+func attack(dice Dice, rules Rules, round int, attacker *player.Player, attackerHealth int, defender *player.Player, defenderHealth int) RoundEvent {
+	//fetching the base attributes of both players
+	attackerName, _, _, attackerAttack := player.GetPlayerBaseAttributes(attacker)
+	defenderName, _, defenderStrength, _ := player.GetPlayerBaseAttributes(defender)
+
+	roundEvent := RoundEvent{
+		Round:      round,
+		AttackerID: player.GetPlayerID(attacker),
+		Attacker:   attackerName,
+		DefenderID: player.GetPlayerID(defender),
+		Defender:   defenderName,
+	}
+	roundEvent.AttackRoll = dice.Roll(rules.DiceFaces)
+	roundEvent.DefenceRoll = dice.Roll(rules.DiceFaces)
+	roundEvent.Attack = attackerAttack * roundEvent.AttackRoll
+	roundEvent.Defence = defenderStrength * roundEvent.DefenceRoll
+	roundEvent.Damage = max(rules.DamageFloor, roundEvent.Attack-roundEvent.Defence)
+	roundEvent.AttackerHealth = attackerHealth
+	roundEvent.DefenderHealth = max(0, defenderHealth-roundEvent.Damage)
+	return roundEvent
 }
 
 //max returns the maximum of two integers
@@ -313,41 +548,45 @@ func GetSwitchCurrentPlayer(currentPlayer **player.Player, playerA *player.Playe
 }
 
 // MatchResult determines the result of a match based on the health attributes of two players.
+// A player whose health is 0 or less has lost; if both players are still standing the match is a Draw.
 //
 // Parameters:
-//   - nameA: The name of Player A.
+//   - playerA: A pointer to Player A.
 //   - healthA: The current health of Player A.
-//   - nameB: The name of Player B.
+//   - playerB: A pointer to Player B.
 //   - healthB: The current health of Player B.
+//   - rounds: The number of rounds played.
 //
 // Returns:
-//   - string: A message indicating the winner of the match. The message is formatted as "{winner} wins".
+//   - Result: The result of the match. Its text form is "{winner} wins" for a Win.
 //
 // Example:
-//   result := MatchResult("PlayerA", 0, "PlayerB", 30)
+//   result := MatchResult(playerA, 0, playerB, 30, 7)
 //   fmt.Println(result) // Output: "PlayerB wins"
-func MatchResult(nameA string, healthA int, nameB string, healthB int) string {
+func MatchResult(playerA *player.Player, healthA int, playerB *player.Player, healthB int, rounds int) Result {
 	if healthA <= 0 {
-		return fmt.Sprintf("%s wins", nameB)
-	} else {
-		return fmt.Sprintf("%s wins", nameA)
+		return Result{Outcome: Win, Winner: playerB, Rounds: rounds}
+	} else if healthB <= 0 {
+		return Result{Outcome: Win, Winner: playerA, Rounds: rounds}
 	}
+	return Result{Outcome: Draw, Rounds: rounds}
 }
 
 // GetMatchResult is a testing wrapper for the MatchResult function.
 //
 // Parameters:
-//   - nameA: The name of Player A.
+//   - playerA: A pointer to Player A.
 //   - healthA: The current health of Player A.
-//   - nameB: The name of Player B.
+//   - playerB: A pointer to Player B.
 //   - healthB: The current health of Player B.
+//   - rounds: The number of rounds played.
 //
 // Returns:
-//   - string: A message indicating the winner of the match. The message is formatted as "{winner} wins".
+//   - Result: The result of the match. Its text form is "{winner} wins" for a Win.
 //
 // Example:
-//   result := GetMatchResult("PlayerA", 10, "PlayerB", 0)
+//   result := GetMatchResult(playerA, 10, playerB, 0, 7)
 //   fmt.Println(result) // Output: "PlayerA wins"
-func GetMatchResult(nameA string, healthA int, nameB string, healthB int) string {
-	return MatchResult(nameA, healthA, nameB, healthB)
+func GetMatchResult(playerA *player.Player, healthA int, playerB *player.Player, healthB int, rounds int) Result {
+	return MatchResult(playerA, healthA, playerB, healthB, rounds)
 }
@@ -1,9 +1,14 @@
 package match
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"magical-arena/pkg/player"
+	"math"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -127,21 +132,23 @@ func TestGetIsMatchOver(t *testing.T) {
 	}
 }
 
+// TestGetConductRound tests conductRound with scripted dice that always roll 4,
+// so the damage of every round is known in advance.
 func TestGetConductRound(t *testing.T) {
-	// TEST 1: creating a current player with name "testA".
+	// TEST 1: creating a current player with name "testA", every die rolls 4.
 	// playerA is the current player, playerB is the opponent
 	// playerA attributes: health 100, strength 10, attack 10
 	// playerB attributes: health 50, strength 5, attack 2
 	//expected health of PlayerB after round = 50 - max(0, 10*4 - 5*4) = 30
 	playerA := player.NewPlayer("testA", 100, 10, 10)
-	//playerB := player.NewPlayer("PlayerB", 50, 5, 2)
+	playerB := player.NewPlayer("PlayerB", 50, 5, 2)
 	currentPlayer := playerA
-	roundResult, healthA, healthB := conductRound(currentPlayer, "testA", 100, 10, 10, "PlayerB", 50, 5, 2)
+	roundResult, healthA, healthB := conductRound(NewScriptedDice(4), DefaultRules(), 1, currentPlayer, playerA, 100, playerB, 50)
 	if healthB != 30 {
 		t.Errorf(redColor+"Expected healthB to be 30, got %d"+resetColor, healthB)
 	}
-	if roundResult != "testA attacked PlayerB for 20 damage" {
-		t.Errorf(redColor+"Expected roundResult to be 'testA attacked PlayerB for 20 damage', got %s"+resetColor, roundResult)
+	if roundResult.String() != "testA attacked PlayerB for 20 damage" {
+		t.Errorf(redColor+"Expected roundResult to be 'testA attacked PlayerB for 20 damage', got %s"+resetColor, roundResult.String())
 	}
 	if healthA != 100 {
 		t.Errorf(redColor+"Expected healthA to be 100, got %d"+resetColor, healthA)
@@ -149,20 +156,20 @@ func TestGetConductRound(t *testing.T) {
 		fmt.Println(greenColor + "TestGetConductRound : Test1 : Passed" + resetColor)
 	}
 
-	// TEST 2: creating a current player with name "testA".
+	// TEST 2: every die rolls 4, creating a current player with name "testA".
 	// playerA is the current player, playerB is the opponent
 	// playerA attributes: health 100, strength 10, attack 4
 	// playerB attributes: health 50, strength 5, attack 2
 	//expected health of PlayerB after round = 50 - max(0, 4*4 - 5*4) = 50
 	playerA = player.NewPlayer("testA", 100, 10, 4)
-	//playerB := player.NewPlayer("PlayerB", 50, 5, 2)
+	playerB = player.NewPlayer("PlayerB", 50, 5, 2)
 	currentPlayer = playerA
-	roundResult, healthA, healthB = conductRound(currentPlayer, "testA", 100, 10, 4, "PlayerB", 50, 5, 2)
+	roundResult, healthA, healthB = conductRound(NewScriptedDice(4), DefaultRules(), 1, currentPlayer, playerA, 100, playerB, 50)
 	if healthB != 50 {
 		t.Errorf(redColor+"Expected healthB to be 50, got %d"+resetColor, healthB)
 	}
-	if roundResult != "testA attacked PlayerB for 0 damage" {
-		t.Errorf(redColor+"Expected roundResult to be 'testA attacked PlayerB for 0 damage', got %s"+resetColor, roundResult)
+	if roundResult.String() != "testA attacked PlayerB for 0 damage" {
+		t.Errorf(redColor+"Expected roundResult to be 'testA attacked PlayerB for 0 damage', got %s"+resetColor, roundResult.String())
 	}
 	if healthA != 100 {
 		t.Errorf(redColor+"Expected healthA to be 100, got %d"+resetColor, healthA)
@@ -170,19 +177,20 @@ func TestGetConductRound(t *testing.T) {
 		fmt.Println(greenColor + "TestGetConductRound : Test2 : Passed" + resetColor)
 	}
 
-	// TEST 3: creating a current player with name "testB".
+	// TEST 3: every die rolls 4, creating a current player with name "testB".
 	// playerB is the current player, playerA is the opponent
 	// playerA attributes:  health 50, strength 5, attack 2
 	// playerB attributes: health 100, strength 10, attack 10
 	// expected health of PlayerA after round = 50 - max(0, 10*4 - 5*4) = 30
-	playerB := player.NewPlayer("testB", 100, 10, 10)
+	playerA = player.NewPlayer("PlayerA", 50, 5, 2)
+	playerB = player.NewPlayer("testB", 100, 10, 10)
 	currentPlayer = playerB
-	roundResult, healthA, healthB = conductRound(currentPlayer, "PlayerA", 50, 5, 2, "testB", 100, 10, 10)
+	roundResult, healthA, healthB = conductRound(NewScriptedDice(4), DefaultRules(), 1, currentPlayer, playerA, 50, playerB, 100)
 	if healthA != 30 {
 		t.Errorf(redColor+"Expected healthA to be 30, got %d"+resetColor, healthA)
 	}
-	if roundResult != "testB attacked PlayerA for 20 damage" {
-		t.Errorf(redColor+"Expected roundResult to be 'testB attacked PlayerA for 20 damage', got %s"+resetColor, roundResult)
+	if roundResult.String() != "testB attacked PlayerA for 20 damage" {
+		t.Errorf(redColor+"Expected roundResult to be 'testB attacked PlayerA for 20 damage', got %s"+resetColor, roundResult.String())
 	}
 	if healthB != 100 {
 		t.Errorf(redColor+"Expected healthB to be 100, got %d"+resetColor, healthB)
@@ -190,19 +198,20 @@ func TestGetConductRound(t *testing.T) {
 		fmt.Println(greenColor + "TestGetConductRound : Test3 : Passed" + resetColor)
 	}
 
-	// TEST 4: creating a current player with name "testB".
+	// TEST 4: every die rolls 4, creating a current player with name "testB".
 	// playerB is the current player, playerA is the opponent
 	// playerA attributes:  health 50, strength 5, attack 2
 	// playerB attributes: health 100, strength 10, attack 4
 	// expected health of PlayerA after round = 50 - max(0, 4*4 - 5*4) = 50
+	playerA = player.NewPlayer("PlayerA", 50, 5, 2)
 	playerB = player.NewPlayer("testB", 100, 10, 4)
 	currentPlayer = playerB
-	roundResult, healthA, healthB = conductRound(currentPlayer, "PlayerA", 50, 5, 2, "testB", 100, 10, 4)
+	roundResult, healthA, healthB = conductRound(NewScriptedDice(4), DefaultRules(), 1, currentPlayer, playerA, 50, playerB, 100)
 	if healthA != 50 {
 		t.Errorf(redColor+"Expected healthA to be 50, got %d"+resetColor, healthA)
 	}
-	if roundResult != "testB attacked PlayerA for 0 damage" {
-		t.Errorf(redColor+"Expected roundResult to be 'testB attacked PlayerA for 0 damage', got %s"+resetColor, roundResult)
+	if roundResult.String() != "testB attacked PlayerA for 0 damage" {
+		t.Errorf(redColor+"Expected roundResult to be 'testB attacked PlayerA for 0 damage', got %s"+resetColor, roundResult.String())
 	}
 	if healthB != 100 {
 		t.Errorf(redColor+"Expected healthB to be 100, got %d"+resetColor, healthB)
@@ -256,24 +265,37 @@ func TestGetSwitchCurrentPlayer(t *testing.T) {
 //      PlayerA wins.
 //   2. Create a match with PlayerA's health 0 and PlayerB's health 100. Check that
 //      PlayerB wins.
+//   3. Create a match with both players still standing. Check that it is a draw.
 func TestGetMatchResult(t *testing.T) {
+	playerA := player.NewPlayer("PlayerA", 100, 10, 5)
+	playerB := player.NewPlayer("PlayerB", 100, 10, 5)
+
 	// TEST 1: playerA wins
-	matchResult := GetMatchResult("PlayerA", 100, "PlayerB", 0)
-	if matchResult != "PlayerA wins" {
+	matchResult := GetMatchResult(playerA, 100, playerB, 0, 10)
+	if matchResult.String() != "PlayerA wins" || matchResult.Outcome != Win || matchResult.Winner != playerA {
 		t.Errorf(redColor+"Expected matchResult to be 'PlayerA wins', got %s"+resetColor, matchResult)
 	} else {
 		fmt.Println(greenColor + "TestGetMatchResult : Test1 : Passed" + resetColor)
 	}
 
 	// TEST 2: playerB wins
-	matchResult = GetMatchResult("PlayerA", 0, "PlayerB", 100)
-	if matchResult != "PlayerB wins" {
+	matchResult = GetMatchResult(playerA, 0, playerB, 100, 10)
+	if matchResult.String() != "PlayerB wins" || matchResult.Outcome != Win || matchResult.Winner != playerB {
 		t.Errorf(redColor+"Expected matchResult to be 'PlayerB wins', got %s"+resetColor, matchResult)
 	} else {
 		fmt.Println(greenColor + "TestGetMatchResult : Test2 : Passed" + resetColor)
 	}
+
+	// TEST 3: both players standing is a draw
+	matchResult = GetMatchResult(playerA, 40, playerB, 60, 10)
+	if matchResult.String() != "Draw after 10 rounds" || matchResult.Outcome != Draw || matchResult.Winner != nil {
+		t.Errorf(redColor+"Expected matchResult to be 'Draw after 10 rounds', got %s"+resetColor, matchResult)
+	} else {
+		fmt.Println(greenColor + "TestGetMatchResult : Test3 : Passed" + resetColor)
+	}
 }
 
+// TestConductMatch tests complete matches played with scripted dice that always roll 4.
 func TestConductMatch(t *testing.T) {
 	//TEST 1: create a match with playerA health 100, playerB health 60
 	// attribute of playerA: name=testA, health=100, strength=20, attack=20
@@ -281,9 +303,9 @@ func TestConductMatch(t *testing.T) {
 	// expected match result: testA wins
 	playerA := player.NewPlayer("testA", 100, 20, 20)
 	playerB := player.NewPlayer("testB", 60, 10, 20)
-	match := NewMatch(playerA, playerB)
+	match := NewMatch(playerA, playerB, WithDice(NewScriptedDice(4)))
 	_, matchResult := ConductMatch(match)
-	if matchResult != "testA wins" {
+	if matchResult.String() != "testA wins" {
 		t.Errorf(redColor+"Expected matchResult to be 'testA wins', got %s"+resetColor, matchResult)
 	} else {
 		fmt.Println(greenColor + "TestConductMatch : Test1 : Passed" + resetColor)
@@ -295,15 +317,502 @@ func TestConductMatch(t *testing.T) {
 	// expected match result: testB wins
 	playerA = player.NewPlayer("testA", 60, 10, 20)
 	playerB = player.NewPlayer("testB", 100, 20, 20)
-	match = NewMatch(playerA, playerB)
+	match = NewMatch(playerA, playerB, WithDice(NewScriptedDice(4)))
 	_, matchResult = ConductMatch(match)
-	if matchResult != "testB wins" {
+	if matchResult.String() != "testB wins" {
 		t.Errorf(redColor+"Expected matchResult to be 'testB wins', got %s"+resetColor, matchResult)
 	} else {
 		fmt.Println(greenColor + "TestConductMatch : Test2 : Passed" + resetColor)
 	}
 }
 
+// TestSeededMatch tests that two matches created with the same seed play out identically.
+func TestSeededMatch(t *testing.T) {
+	// TEST 1: two matches between the same players with seed 42 produce the same rounds and result
+	playerA := player.NewPlayer("PlayerA", 50, 5, 10)
+	playerB := player.NewPlayer("PlayerB", 100, 10, 5)
+	first := NewMatch(playerA, playerB, WithSeed(42))
+	second := NewMatch(playerA, playerB, WithSeed(42))
+	firstRounds, firstResult := ConductMatch(first)
+	secondRounds, secondResult := ConductMatch(second)
+	if first.Seed() != 42 || second.Seed() != 42 {
+		t.Errorf(redColor+"Expected both seeds to be 42, got %d and %d"+resetColor, first.Seed(), second.Seed())
+	}
+	if firstResult != secondResult || fmt.Sprint(firstRounds) != fmt.Sprint(secondRounds) {
+		t.Errorf(redColor+"Expected seeded matches to be identical, got %q and %q"+resetColor, firstResult, secondResult)
+	} else {
+		fmt.Println(greenColor + "TestSeededMatch : Test1 : Passed" + resetColor)
+	}
+}
+
+// TestRoundEvent tests that conductRound records the dice, attack, defence, damage and health of a round.
+func TestRoundEvent(t *testing.T) {
+	// TEST 1: PlayerA (attack 10) rolls 6, PlayerB (strength 5) rolls 2
+	// expected attack 60, defence 10, damage 50, PlayerB health 80 - 50 = 30
+	playerA := player.NewPlayer("PlayerA", 100, 10, 10)
+	playerB := player.NewPlayer("PlayerB", 80, 5, 2)
+	event, _, _ := conductRound(NewScriptedDice(6, 2), DefaultRules(), 3, playerA, playerA, 100, playerB, 80)
+	expected := RoundEvent{
+		Round:          3,
+		AttackerID:     player.GetPlayerID(playerA),
+		Attacker:       "PlayerA",
+		DefenderID:     player.GetPlayerID(playerB),
+		Defender:       "PlayerB",
+		AttackRoll:     6,
+		DefenceRoll:    2,
+		Attack:         60,
+		Defence:        10,
+		Damage:         50,
+		AttackerHealth: 100,
+		DefenderHealth: 30,
+	}
+	if event != expected {
+		t.Errorf(redColor+"Expected event to be %+v, got %+v"+resetColor, expected, event)
+	} else {
+		fmt.Println(greenColor + "TestRoundEvent : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: the text form of the event is generated from its fields
+	if event.String() != "PlayerA attacked PlayerB for 50 damage" {
+		t.Errorf(redColor+"Expected 'PlayerA attacked PlayerB for 50 damage', got %s"+resetColor, event.String())
+	} else {
+		fmt.Println(greenColor + "TestRoundEvent : Test2 : Passed" + resetColor)
+	}
+}
+
+// TestNextRound tests driving a match one round at a time with NextRound, IsOver and State.
+func TestNextRound(t *testing.T) {
+	// TEST 1: PlayerA (health 50) starts; every die rolls 4 so each hit deals 4*10 - 4*5 = 20 damage
+	playerA := player.NewPlayer("PlayerA", 50, 5, 10)
+	playerB := player.NewPlayer("PlayerB", 60, 5, 10)
+	match := NewMatch(playerA, playerB, WithDice(NewScriptedDice(4)))
+	state := match.State()
+	if state.Round != 0 || state.Attacker != playerA || state.HealthA != 50 || state.HealthB != 60 || state.Over {
+		t.Errorf(redColor+"Unexpected initial state %+v"+resetColor, state)
+	} else {
+		fmt.Println(greenColor + "TestNextRound : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 1b: WithFirstAttacker overrides the lower-health rule
+	if first := NewMatch(playerA, playerB, WithFirstAttacker(playerB)).State().Attacker; first != playerB {
+		t.Errorf(redColor+"Expected PlayerB to attack first"+resetColor)
+	}
+
+	// TEST 2: after one round PlayerB has 40 health and it is PlayerB's turn
+	event, err := match.NextRound()
+	state = match.State()
+	if err != nil || event.Damage != 20 || state.Round != 1 || state.Attacker != playerB || state.HealthB != 40 {
+		t.Errorf(redColor+"Unexpected state after round 1: %+v (err %v)"+resetColor, state, err)
+	} else {
+		fmt.Println(greenColor + "TestNextRound : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: the match ends after round 5 (B 40 -> 20 -> 0 while A 50 -> 30 -> 10) and further rounds fail
+	for !match.IsOver() {
+		match.NextRound()
+	}
+	_, err = match.NextRound()
+	state = match.State()
+	if state.Round != 5 || state.HealthB != 0 || state.HealthA != 10 || match.Result().String() != "PlayerA wins" || err != ErrMatchOver {
+		t.Errorf(redColor+"Unexpected final state %+v (err %v)"+resetColor, state, err)
+	} else {
+		fmt.Println(greenColor + "TestNextRound : Test3 : Passed" + resetColor)
+	}
+}
+
+// TestMatchLimits tests that matches end in a Stalemate or a Draw instead of running forever.
+func TestMatchLimits(t *testing.T) {
+	// TEST 1: neither player can damage the other, so the match is a stalemate after 20 zero-damage rounds
+	playerA := player.NewPlayer("PlayerA", 50, 100, 1)
+	playerB := player.NewPlayer("PlayerB", 50, 100, 1)
+	match := NewMatch(playerA, playerB, WithSeed(1), WithStalemateRounds(20))
+	events, result := ConductMatch(match)
+	if result.Outcome != Stalemate || len(events) != 20 || result.String() != "Stalemate after 20 rounds" {
+		t.Errorf(redColor+"Expected a stalemate after 20 rounds, got %s (%d events)"+resetColor, result, len(events))
+	} else {
+		fmt.Println(greenColor + "TestMatchLimits : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: each hit deals 4 damage to 100-health players, so a 10 round limit ends in a draw
+	playerA = player.NewPlayer("PlayerA", 100, 1, 2)
+	playerB = player.NewPlayer("PlayerB", 100, 1, 2)
+	match = NewMatch(playerA, playerB, WithDice(NewScriptedDice(4)), WithMaxRounds(10))
+	events, result = ConductMatch(match)
+	if result.Outcome != Draw || len(events) != 10 || result.Winner != nil {
+		t.Errorf(redColor+"Expected a draw after 10 rounds, got %s (%d events)"+resetColor, result, len(events))
+	} else {
+		fmt.Println(greenColor + "TestMatchLimits : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: a player entering with no health loses before any round is played
+	playerA = player.NewPlayer("PlayerA", 0, 1, 2)
+	match = NewMatch(playerA, playerB, WithSeed(1))
+	events, result = ConductMatch(match)
+	if result.Outcome != Win || result.Winner != playerB || len(events) != 0 {
+		t.Errorf(redColor+"Expected PlayerB to win without rounds, got %s (%d events)"+resetColor, result, len(events))
+	} else {
+		fmt.Println(greenColor + "TestMatchLimits : Test3 : Passed" + resetColor)
+	}
+}
+
+// cancellingDice wraps a Dice and cancels a context once a number of rolls have been made.
+type cancellingDice struct {
+	dice   Dice
+	rolls  int
+	cancel context.CancelFunc
+}
+
+func (d *cancellingDice) Roll(faces int) int {
+	d.rolls--
+	if d.rolls == 0 {
+		d.cancel()
+	}
+	return d.dice.Roll(faces)
+}
+
+// TestConductMatchContext tests that a match stops between rounds when its context is cancelled.
+func TestConductMatchContext(t *testing.T) {
+	// TEST 1: the context is cancelled during round 3 (each round rolls two dice), so 3 rounds are played
+	playerA := player.NewPlayer("PlayerA", 100, 1, 2)
+	playerB := player.NewPlayer("PlayerB", 100, 1, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dice := &cancellingDice{dice: NewScriptedDice(4), rolls: 6, cancel: cancel}
+	match := NewMatch(playerA, playerB, WithDice(dice))
+	events, result, err := ConductMatchContext(ctx, match)
+	if err != context.Canceled || result.Outcome != Aborted || len(events) != 3 || !match.IsOver() {
+		t.Errorf(redColor+"Expected an aborted match after 3 rounds, got %s (%d events, err %v)"+resetColor, result, len(events), err)
+	} else {
+		fmt.Println(greenColor + "TestConductMatchContext : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: an uncancelled context lets the match finish normally
+	match = NewMatch(playerA, playerB, WithDice(NewScriptedDice(4)))
+	_, result, err = ConductMatchContext(context.Background(), match)
+	if err != nil || result.Outcome != Win {
+		t.Errorf(redColor+"Expected the match to be won, got %s (err %v)"+resetColor, result, err)
+	} else {
+		fmt.Println(greenColor + "TestConductMatchContext : Test2 : Passed" + resetColor)
+	}
+}
+
+// TestWinProbability tests the exact win-probability calculator against known and simulated results.
+func TestWinProbability(t *testing.T) {
+	// TEST 1: PlayerA starts (lower health) and always deals at least 100 - 6 = 94 damage, so A wins in 1 round
+	playerA := player.NewPlayer("PlayerA", 10, 1, 100)
+	playerB := player.NewPlayer("PlayerB", 10, 1, 1)
+	odds := WinProbability(playerA, playerB, DefaultRules())
+	if math.Abs(odds.WinA-1) > 1e-9 || math.Abs(odds.WinB) > 1e-9 || math.Abs(odds.ExpectedRounds-1) > 1e-9 {
+		t.Errorf(redColor+"Expected A to win in 1 round, got %+v"+resetColor, odds)
+	} else {
+		fmt.Println(greenColor + "TestWinProbability : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: players who cannot damage each other never finish
+	playerA = player.NewPlayer("PlayerA", 50, 100, 1)
+	playerB = player.NewPlayer("PlayerB", 50, 100, 1)
+	odds = WinProbability(playerA, playerB, DefaultRules())
+	if odds.Stalemate != 1 || odds.WinA != 0 || odds.WinB != 0 || !math.IsInf(odds.ExpectedRounds, 1) {
+		t.Errorf(redColor+"Expected a certain stalemate, got %+v"+resetColor, odds)
+	} else {
+		fmt.Println(greenColor + "TestWinProbability : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: the exact odds agree with 20000 seeded matches to within 2%
+	playerA = player.NewPlayer("PlayerA", 50, 5, 10)
+	playerB = player.NewPlayer("PlayerB", 100, 10, 5)
+	odds = WinProbability(playerA, playerB, DefaultRules())
+	wins, rounds := 0, 0
+	const runs = 20000
+	for seed := int64(0); seed < runs; seed++ {
+		events, result := ConductMatch(NewMatch(playerA, playerB, WithSeed(seed)))
+		if result.Winner == playerA {
+			wins++
+		}
+		rounds += len(events)
+	}
+	simulatedWinA := float64(wins) / runs
+	simulatedRounds := float64(rounds) / runs
+	if math.Abs(odds.WinA+odds.WinB-1) > 1e-9 || math.Abs(odds.WinA-simulatedWinA) > 0.02 || math.Abs(odds.ExpectedRounds-simulatedRounds)/simulatedRounds > 0.02 {
+		t.Errorf(redColor+"Expected odds %+v to match simulation (WinA %.3f, rounds %.2f)"+resetColor, odds, simulatedWinA, simulatedRounds)
+	} else {
+		fmt.Println(greenColor + "TestWinProbability : Test3 : Passed" + resetColor)
+	}
+
+	// TEST 4: a damage floor of 1 turns the stalemate of TEST 2 into a race that the starting PlayerA wins in 99 rounds
+	rules := DefaultRules()
+	rules.DamageFloor = 1
+	odds = WinProbability(player.NewPlayer("PlayerA", 50, 100, 1), player.NewPlayer("PlayerB", 50, 100, 1), rules)
+	if math.Abs(odds.WinA-1) > 1e-9 || odds.Stalemate != 0 || math.Abs(odds.ExpectedRounds-99) > 1e-9 {
+		t.Errorf(redColor+"Expected A to win in 99 rounds, got %+v"+resetColor, odds)
+	} else {
+		fmt.Println(greenColor + "TestWinProbability : Test4 : Passed" + resetColor)
+	}
+
+	// TEST 5: the exact odds follow custom dice, damage floor and a random start, in agreement with seeded matches
+	rules = DefaultRules()
+	rules.DiceFaces, rules.DamageFloor, rules.StartingPlayer = 10, 2, StartRandom
+	odds = WinProbability(playerA, playerB, rules)
+	wins, rounds = 0, 0
+	for seed := int64(0); seed < runs; seed++ {
+		events, result := ConductMatch(NewMatch(playerA, playerB, WithSeed(seed), WithRules(rules)))
+		if result.Winner == playerA {
+			wins++
+		}
+		rounds += len(events)
+	}
+	simulatedWinA = float64(wins) / runs
+	simulatedRounds = float64(rounds) / runs
+	if math.Abs(odds.WinA-simulatedWinA) > 0.02 || math.Abs(odds.ExpectedRounds-simulatedRounds)/simulatedRounds > 0.02 {
+		t.Errorf(redColor+"Expected odds %+v to match simulation (WinA %.3f, rounds %.2f)"+resetColor, odds, simulatedWinA, simulatedRounds)
+	} else {
+		fmt.Println(greenColor + "TestWinProbability : Test5 : Passed" + resetColor)
+	}
+}
+
+// TestSameNamePlayers tests that two players with the same name can fight, since players are identified by ID,
+// while a player cannot fight themselves.
+func TestSameNamePlayers(t *testing.T) {
+	// TEST 1: both players are called "Twin"; turns alternate between the two distinct players
+	playerA := player.NewPlayer("Twin", 50, 5, 10)
+	playerB := player.NewPlayer("Twin", 60, 5, 10)
+	events, result := ConductMatch(NewMatch(playerA, playerB, WithDice(NewScriptedDice(4))))
+	alternates := len(events) == 5
+	for i, event := range events {
+		attacker, defender := playerA, playerB
+		if i%2 == 1 {
+			attacker, defender = playerB, playerA
+		}
+		if event.AttackerID != player.GetPlayerID(attacker) || event.DefenderID != player.GetPlayerID(defender) {
+			alternates = false
+		}
+	}
+	if !alternates || result.Winner != playerA {
+		t.Errorf(redColor+"Expected playerA to win in 5 alternating rounds, got %s after %+v"+resetColor, result, events)
+	} else {
+		fmt.Println(greenColor + "TestSameNamePlayers : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: a player facing themselves, or a copy with the same ID, cannot play: the match is aborted before any round
+	copyA := player.NewPlayerWithID(player.GetPlayerID(playerA), "Twin", 50, 5, 10)
+	for _, m := range []*Match{NewMatch(playerA, playerA), NewMatch(playerA, copyA)} {
+		_, err := m.NextRound()
+		events, result := ConductMatch(m)
+		if !errors.Is(err, ErrSamePlayer) || len(events) != 0 || result.Outcome != Aborted || result.Rounds != 0 {
+			t.Errorf(redColor+"Expected an aborted match against themselves, got %v, %s after %+v"+resetColor, err, result, events)
+			return
+		}
+	}
+	fmt.Println(greenColor + "TestSameNamePlayers : Test2 : Passed" + resetColor)
+}
+
+// TestScriptedDice tests that ScriptedDice returns its rolls in order and starts over when exhausted.
+func TestScriptedDice(t *testing.T) {
+	// TEST 1: rolls 1, 6, 3 are returned in order, then repeated
+	dice := NewScriptedDice(1, 6, 3)
+	expected := []int{1, 6, 3, 1, 6}
+	for i, want := range expected {
+		if got := dice.Roll(6); got != want {
+			t.Errorf(redColor+"Expected roll %d to be %d, got %d"+resetColor, i, want, got)
+			return
+		}
+	}
+	fmt.Println(greenColor + "TestScriptedDice : Test1 : Passed" + resetColor)
+
+	// TEST 2: dice without rolls always roll 1 instead of panicking
+	if got := NewScriptedDice().Roll(6); got != 1 {
+		t.Errorf(redColor+"Expected an empty script to roll 1, got %d"+resetColor, got)
+	} else {
+		fmt.Println(greenColor + "TestScriptedDice : Test2 : Passed" + resetColor)
+	}
+}
+
+// TestOutcomeText tests that outcomes round-trip through their text form.
+func TestOutcomeText(t *testing.T) {
+	// TEST 1: every outcome decodes back from its name
+	for outcome := Undecided; outcome <= Aborted; outcome++ {
+		text, _ := outcome.MarshalText()
+		var decoded Outcome
+		if err := decoded.UnmarshalText(text); err != nil || decoded != outcome {
+			t.Errorf(redColor+"Expected %v to round-trip, got %v, %v"+resetColor, outcome, decoded, err)
+			return
+		}
+	}
+	fmt.Println(greenColor + "TestOutcomeText : Test1 : Passed" + resetColor)
+
+	// TEST 2: unknown names are rejected
+	var decoded Outcome
+	if err := decoded.UnmarshalText([]byte("victory")); err == nil {
+		t.Errorf(redColor + "Expected an error for an unknown outcome" + resetColor)
+	} else {
+		fmt.Println(greenColor + "TestOutcomeText : Test2 : Passed" + resetColor)
+	}
+}
+
+// TestReplay tests that recorded matches replay exactly, and that tampered replays are rejected.
+func TestReplay(t *testing.T) {
+	playerA := player.NewPlayer("PlayerA", 100, 5, 10)
+	playerB := player.NewPlayer("PlayerB", 50, 5, 10)
+
+	// TEST 1: a seeded match survives a JSON round trip and replays to the same result
+	original := NewMatch(playerA, playerB, WithSeed(99), WithStalemateRounds(50))
+	_, want := ConductMatch(original)
+	data, err := json.Marshal(RecordReplay(original))
+	if err != nil {
+		t.Fatalf(redColor+"Unexpected error %v"+resetColor, err)
+	}
+	var replay ReplayLog
+	if err := json.Unmarshal(data, &replay); err != nil {
+		t.Fatalf(redColor+"Unexpected error %v"+resetColor, err)
+	}
+	got, err := Replay(replay)
+	if err != nil || got.Outcome != want.Outcome || got.Rounds != want.Rounds ||
+		idOrEmpty(got.Winner) != idOrEmpty(want.Winner) || len(replay.Rolls) != 2*want.Rounds {
+		t.Errorf(redColor+"Expected %v, got %v, %v"+resetColor, want, got, err)
+	} else {
+		fmt.Println(greenColor + "TestReplay : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: a changed roll, a changed outcome and extra rolls are all reported as mismatches
+	changedRoll := RecordReplay(original)
+	changedRoll.Rolls[0] = changedRoll.Rolls[0]%6 + 1
+	changedOutcome := RecordReplay(original)
+	changedOutcome.Outcome = Draw
+	extraRolls := RecordReplay(original)
+	extraRolls.Rolls = append(extraRolls.Rolls, 3, 3)
+	for i, tampered := range []ReplayLog{changedRoll, changedOutcome, extraRolls} {
+		if _, err := Replay(tampered); !errors.Is(err, ErrReplayMismatch) {
+			t.Errorf(redColor+"Expected tampered replay %d to mismatch, got %v"+resetColor, i, err)
+			return
+		}
+	}
+	fmt.Println(greenColor + "TestReplay : Test2 : Passed" + resetColor)
+
+	// TEST 3: a match that ends before the first round replays without rolls
+	fallen := NewMatch(player.NewPlayer("Fallen", 0, 5, 10), playerB)
+	if _, err := Replay(RecordReplay(fallen)); err != nil {
+		t.Errorf(redColor+"Unexpected error %v"+resetColor, err)
+	} else {
+		fmt.Println(greenColor + "TestReplay : Test3 : Passed" + resetColor)
+	}
+
+	// TEST 4: an aborted match replays up to its recorded rounds and is aborted again
+	aborted := NewMatch(playerA, playerB, WithSeed(5))
+	for i := 0; i < 3; i++ {
+		aborted.NextRound()
+	}
+	aborted.Abort()
+	got, err = Replay(RecordReplay(aborted))
+	if err != nil || got.Outcome != Aborted || got.Rounds != 3 {
+		t.Errorf(redColor+"Expected an aborted replay after 3 rounds, got %v, %v"+resetColor, got, err)
+	} else {
+		fmt.Println(greenColor + "TestReplay : Test4 : Passed" + resetColor)
+	}
+}
+
+// idOrEmpty returns the ID of a player, or an empty string for nil.
+func idOrEmpty(p *player.Player) string {
+	if p == nil {
+		return ""
+	}
+	return player.GetPlayerID(p)
+}
+
+// facesDice is a Dice that records the number of faces it was asked to roll and always rolls 1.
+type facesDice struct {
+	faces []int
+}
+
+// Roll records the number of faces and returns 1.
+func (d *facesDice) Roll(faces int) int {
+	d.faces = append(d.faces, faces)
+	return 1
+}
+
+// TestRules tests loading rules and playing matches by custom rules.
+func TestRules(t *testing.T) {
+	playerA := player.NewPlayer("PlayerA", 100, 10, 5)
+	playerB := player.NewPlayer("PlayerB", 50, 10, 5)
+
+	// TEST 1: fields missing from the JSON keep their defaults, and invalid rules are rejected
+	rules, err := DecodeRules(strings.NewReader(`{"diceFaces": 20, "damageFloor": 3, "startingPlayer": "higher-health"}`))
+	want := DefaultRules()
+	want.DiceFaces, want.DamageFloor, want.StartingPlayer = 20, 3, StartHigherHealth
+	_, badFaces := DecodeRules(strings.NewReader(`{"diceFaces": 0}`))
+	_, badPolicy := DecodeRules(strings.NewReader(`{"startingPlayer": "loudest"}`))
+	_, badField := DecodeRules(strings.NewReader(`{"dice": 6}`))
+	if err != nil || rules != want || !errors.Is(badFaces, ErrInvalidRules) || !errors.Is(badPolicy, ErrInvalidRules) || !errors.Is(badField, ErrInvalidRules) {
+		t.Errorf(redColor+"Unexpected rules %+v, %v, %v, %v, %v"+resetColor, rules, err, badFaces, badPolicy, badField)
+	} else {
+		fmt.Println(greenColor + "TestRules : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: the dice faces and damage floor apply to every attack, and the starting policy picks the first attacker
+	dice := &facesDice{}
+	m := NewMatch(playerA, playerB, WithRules(rules), WithDice(dice))
+	event, _ := m.NextRound()
+	if event.Attacker != "PlayerA" || event.Damage != 3 || event.DefenderHealth != 47 || len(dice.faces) != 2 || dice.faces[0] != 20 {
+		t.Errorf(redColor+"Unexpected round %+v with faces %v"+resetColor, event, dice.faces)
+	} else {
+		fmt.Println(greenColor + "TestRules : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: the round limits of the rules apply, and options given afterwards override them
+	limited := DefaultRules()
+	limited.MaxRounds = 3
+	_, drawn := ConductMatch(NewMatch(playerA, player.NewPlayer("Wall", 100, 100, 5), WithRules(limited)))
+	_, overridden := ConductMatch(NewMatch(playerA, player.NewPlayer("Wall", 100, 100, 5), WithRules(limited), WithMaxRounds(5), WithStalemateRounds(0)))
+	if drawn.Outcome != Draw || drawn.Rounds != 3 || overridden.Rounds != 5 {
+		t.Errorf(redColor+"Unexpected limited results %v, %v"+resetColor, drawn, overridden)
+	} else {
+		fmt.Println(greenColor + "TestRules : Test3 : Passed" + resetColor)
+	}
+
+	// TEST 4: the validation thresholds follow the rules, with the default messages
+	strict := DefaultRules()
+	strict.MinHealth = 80
+	weak := player.NewPlayer("Weak", 100, 10, 1)
+	defaultErr := DefaultRules().ValidatePlayers(playerA, weak)
+	lenient := DefaultRules()
+	lenient.RequireDamage = false
+	if DefaultRules().ValidatePlayers(playerA, playerB) != nil || strict.ValidatePlayers(playerA, playerB) == nil ||
+		defaultErr == nil || defaultErr.Error() != "Player 2 attack is too low to damage Player 1." || lenient.ValidatePlayers(playerA, weak) != nil {
+		t.Errorf(redColor+"Unexpected validation result %v"+resetColor, defaultErr)
+	} else {
+		fmt.Println(greenColor + "TestRules : Test4 : Passed" + resetColor)
+	}
+
+	// TEST 5: a match played by custom rules replays by the same rules
+	custom := NewMatch(playerA, playerB, WithRules(rules), WithSeed(5))
+	ConductMatch(custom)
+	if _, err := Replay(RecordReplay(custom)); err != nil {
+		t.Errorf(redColor+"Unexpected replay error %v"+resetColor, err)
+	} else {
+		fmt.Println(greenColor + "TestRules : Test5 : Passed" + resetColor)
+	}
+
+	// TEST 6: rules under which a match could never end are rejected, while any one way of ending is enough
+	_, endless := DecodeRules(strings.NewReader(`{"requireDamage": false, "stalemateRounds": 0}`))
+	_, capped := DecodeRules(strings.NewReader(`{"requireDamage": false, "stalemateRounds": 0, "maxRounds": 100}`))
+	_, floored := DecodeRules(strings.NewReader(`{"requireDamage": false, "stalemateRounds": 0, "damageFloor": 1}`))
+	_, required := DecodeRules(strings.NewReader(`{"stalemateRounds": 0}`))
+	if !errors.Is(endless, ErrInvalidRules) || capped != nil || floored != nil || required != nil {
+		t.Errorf(redColor+"Unexpected validation of endless rules %v, %v, %v, %v"+resetColor, endless, capped, floored, required)
+	} else {
+		fmt.Println(greenColor + "TestRules : Test6 : Passed" + resetColor)
+	}
+
+	// TEST 7: a player cannot fight themselves, nor a copy of themselves with the same ID
+	copyA := player.NewPlayerWithID(player.GetPlayerID(playerA), "PlayerA", 100, 10, 5)
+	selfErr := DefaultRules().ValidatePlayers(playerA, playerA)
+	copyErr := DefaultRules().ValidatePlayers(copyA, playerA)
+	if selfErr == nil || selfErr.Error() != "Player 1 and Player 2 must be different players." || copyErr == nil {
+		t.Errorf(redColor+"Unexpected validation of a player against themselves %v, %v"+resetColor, selfErr, copyErr)
+	} else {
+		fmt.Println(greenColor + "TestRules : Test7 : Passed" + resetColor)
+	}
+}
+
 // TestMain runs the main testing suite.
 func TestMain(m *testing.M) {
 	fmt.Println("Testing Match package...")
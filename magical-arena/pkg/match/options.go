@@ -0,0 +1,146 @@
+package match
+
+import "magical-arena/pkg/player"
+
+// DefaultStalemateRounds is the number of consecutive zero-damage rounds after which a match
+// is declared a stalemate, unless WithStalemateRounds says otherwise.
+const DefaultStalemateRounds = 1000
+
+// settings holds the configuration shared by every kind of match: the dice, the round limits and the rules.
+type settings struct {
+	// dice is the source of die rolls used to resolve each round.
+	dice Dice
+
+	// seed is the seed the default dice were created from.
+	seed int64
+
+	// maxRounds is the maximum number of rounds before the match ends in a Draw, 0 for no limit.
+	maxRounds int
+
+	// stalemateRounds is the number of consecutive zero-damage rounds before the match ends in a Stalemate, 0 to disable.
+	stalemateRounds int
+
+	// firstAttacker overrides the starting player of a two-player match when set.
+	firstAttacker *player.Player
+
+	// rules are the dice faces, starting player policy and damage floor of the match.
+	rules Rules
+}
+
+// Option configures optional behaviour of a match created by NewMatch and the other match constructors.
+type Option func(*settings)
+
+// newSettings returns the default settings with the given options applied.
+// Unless a Dice or seed is supplied, the dice are seeded from the current time.
+func newSettings(opts []Option) settings {
+	s := settings{stalemateRounds: DefaultStalemateRounds, rules: DefaultRules()}
+	WithSeed(newSeed())(&s)
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// limitOutcome applies the stalemate rule and the round limit after a round.
+//
+// Parameters:
+//   - round: The number of rounds played so far.
+//   - zeroDamageRounds: The number of consecutive rounds in which no damage was dealt.
+//
+// Returns:
+//   - Outcome: Stalemate or Draw if a limit was reached, Undecided otherwise.
+func (s *settings) limitOutcome(round, zeroDamageRounds int) Outcome {
+	switch {
+	case s.stalemateRounds > 0 && zeroDamageRounds >= s.stalemateRounds:
+		return Stalemate
+	case s.maxRounds > 0 && round >= s.maxRounds:
+		return Draw
+	}
+	return Undecided
+}
+
+// WithSeed makes the match roll its dice from a pseudo-random source initialised with the given seed,
+// so that the match can be reproduced exactly by creating it again with the same seed.
+//
+// Parameters:
+//   - seed: The seed for the match dice.
+//
+// Returns:
+//   - Option: An option to pass to NewMatch.
+//
+// Example:
+//   match := NewMatch(player1, player2, WithSeed(42))
+func WithSeed(seed int64) Option {
+	return func(s *settings) {
+		s.seed = seed
+		s.dice = NewSeededDice(seed)
+	}
+}
+
+// WithDice makes the match roll the given dice instead of its default seeded dice.
+//
+// Parameters:
+//   - dice: The Dice used to resolve every round of the match.
+//
+// Returns:
+//   - Option: An option to pass to NewMatch.
+//
+// Example:
+//   match := NewMatch(player1, player2, WithDice(NewScriptedDice(4)))
+func WithDice(dice Dice) Option {
+	return func(s *settings) {
+		s.dice = dice
+	}
+}
+
+// WithMaxRounds limits the number of rounds of the match. When the limit is reached with both
+// players still standing, the match ends in a Draw. A limit of 0 (the default) means no limit.
+//
+// Parameters:
+//   - rounds: The maximum number of rounds, or 0 for no limit.
+//
+// Returns:
+//   - Option: An option to pass to NewMatch.
+//
+// Example:
+//   match := NewMatch(player1, player2, WithMaxRounds(200))
+func WithMaxRounds(rounds int) Option {
+	return func(s *settings) {
+		s.maxRounds = rounds
+	}
+}
+
+// WithStalemateRounds sets the number of consecutive zero-damage rounds after which the match ends in a Stalemate.
+// The default is DefaultStalemateRounds. A value of 0 disables the rule, in which case a match between
+// players who cannot damage each other never ends unless WithMaxRounds is used as well.
+//
+// Parameters:
+//   - rounds: The number of consecutive zero-damage rounds, or 0 to disable the rule.
+//
+// Returns:
+//   - Option: An option to pass to NewMatch.
+//
+// Example:
+//   match := NewMatch(player1, player2, WithStalemateRounds(50))
+func WithStalemateRounds(rounds int) Option {
+	return func(s *settings) {
+		s.stalemateRounds = rounds
+	}
+}
+
+// WithFirstAttacker makes the given player attack first in a two-player match, instead of the player
+// chosen by the starting player policy of the rules. It has no effect if the player is not part of the match.
+//
+// Parameters:
+//   - first: A pointer to the player who attacks in the first round.
+//
+// Returns:
+//   - Option: An option to pass to NewMatch.
+//
+// Example:
+//   match := NewMatch(player1, player2, WithFirstAttacker(player2))
+func WithFirstAttacker(first *player.Player) Option {
+	return func(s *settings) {
+		s.firstAttacker = first
+	}
+}
@@ -0,0 +1,95 @@
+package match
+
+import (
+	"fmt"
+	"magical-arena/pkg/player"
+)
+
+// Outcome is the kind of result a match ended with.
+type Outcome int
+
+const (
+	// Undecided means the match has not finished yet.
+	Undecided Outcome = iota
+
+	// Win means one player brought the other player's health down to 0.
+	Win
+
+	// Draw means the maximum number of rounds was reached with both players still standing.
+	Draw
+
+	// Stalemate means too many consecutive rounds passed without either player dealing damage.
+	Stalemate
+
+	// Aborted means the match was stopped before it could finish.
+	Aborted
+)
+
+// String returns the lower-case name of the outcome, e.g. "win" or "stalemate".
+func (o Outcome) String() string {
+	switch o {
+	case Undecided:
+		return "undecided"
+	case Win:
+		return "win"
+	case Draw:
+		return "draw"
+	case Stalemate:
+		return "stalemate"
+	case Aborted:
+		return "aborted"
+	default:
+		return fmt.Sprintf("Outcome(%d)", int(o))
+	}
+}
+
+// MarshalText encodes the outcome as its lower-case name, so outcomes read naturally in JSON.
+func (o Outcome) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText decodes an outcome from its lower-case name, as produced by MarshalText.
+func (o *Outcome) UnmarshalText(text []byte) error {
+	for candidate := Undecided; candidate <= Aborted; candidate++ {
+		if candidate.String() == string(text) {
+			*o = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("match: unknown outcome %q", text)
+}
+
+// Result is the typed result of a match.
+type Result struct {
+	// Outcome is the kind of result the match ended with.
+	Outcome Outcome
+
+	// Winner is the winning player when Outcome is Win, and nil otherwise.
+	Winner *player.Player
+
+	// Rounds is the number of rounds played.
+	Rounds int
+}
+
+// String returns the text form of the result, e.g. "PlayerA wins" or "Stalemate after 1000 rounds".
+//
+// Returns:
+//   - string: A message describing the result of the match.
+//
+// Example:
+//   fmt.Println("Match result: " + result.String())
+func (r Result) String() string {
+	switch r.Outcome {
+	case Win:
+		name, _, _, _ := player.GetPlayerBaseAttributes(r.Winner)
+		return fmt.Sprintf("%s wins", name)
+	case Draw:
+		return fmt.Sprintf("Draw after %d rounds", r.Rounds)
+	case Stalemate:
+		return fmt.Sprintf("Stalemate after %d rounds", r.Rounds)
+	case Aborted:
+		return fmt.Sprintf("Match aborted after %d rounds", r.Rounds)
+	default:
+		return ""
+	}
+}
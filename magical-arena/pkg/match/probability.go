@@ -0,0 +1,160 @@
+package match
+
+import (
+	"magical-arena/pkg/player"
+	"math"
+	"sort"
+)
+
+// Probability describes the exact odds of a match between two players.
+type Probability struct {
+	// WinA is the probability that Player A wins the match.
+	WinA float64
+
+	// WinB is the probability that Player B wins the match.
+	WinB float64
+
+	// Stalemate is the probability that the match never ends because neither player can damage the other.
+	Stalemate float64
+
+	// ExpectedRounds is the expected number of rounds of the match, or +Inf when it never ends.
+	ExpectedRounds float64
+}
+
+// WinProbability computes the exact probability of each player winning a match played by the given rules,
+// and the expected number of rounds, without simulating it. The starting player follows the rules' starting policy,
+// a random start counting as a fair coin flip, and every round deals attack*roll - strength*roll damage with dice of
+// the rules' number of faces, raised to the rules' damage floor. Round limits and the stalemate rule are not taken into account.
+//
+// The computation is a dynamic program over (healthA, healthB, whose turn), so its cost grows with the product of the players' health.
+//
+// Parameters:
+//   - playerA: A pointer to Player A.
+//   - playerB: A pointer to Player B.
+//   - rules: The rules of the match, usually DefaultRules().
+//
+// Returns:
+//   - Probability: The win probabilities of both players and the expected number of rounds.
+//
+// Example:
+//   odds := WinProbability(player1, player2, DefaultRules())
+//   fmt.Printf("A wins %.1f%%, B wins %.1f%%, %.1f rounds on average\n", odds.WinA*100, odds.WinB*100, odds.ExpectedRounds)
+func WinProbability(playerA, playerB *player.Player, rules Rules) Probability {
+	//extracting the attributes of the players
+	_, healthA, strengthA, attackA := player.GetPlayerBaseAttributes(playerA)
+	_, healthB, strengthB, attackB := player.GetPlayerBaseAttributes(playerB)
+
+	//a player who enters with no health has already lost
+	if healthA <= 0 {
+		return Probability{WinB: 1}
+	}
+	if healthB <= 0 {
+		return Probability{WinA: 1}
+	}
+
+	//damage distributions of A hitting B and B hitting A, and the chance of a round dealing no damage
+	damageA := damageDistribution(rules, attackA, strengthB)
+	damageB := damageDistribution(rules, attackB, strengthA)
+	zeroA, zeroB := damageA[0].probability, damageB[0].probability
+	if zeroA == 1 && zeroB == 1 {
+		return Probability{Stalemate: 1, ExpectedRounds: math.Inf(1)}
+	}
+
+	// winX[hA][hB] and roundsX[hA][hB] hold the probability that A wins and the expected number of remaining
+	// rounds when A is about to attack with the given health; winY and roundsY are the same when B is about to attack.
+	// Index 0 is never read: a health of 0 or less ends the match.
+	winX, winY := newTable(healthA, healthB), newTable(healthA, healthB)
+	roundsX, roundsY := newTable(healthA, healthB), newTable(healthA, healthB)
+
+	for hA := 1; hA <= healthA; hA++ {
+		for hB := 1; hB <= healthB; hB++ {
+			// sumX: A attacks and deals damage, then it is B's turn with lower hB (already computed).
+			sumWinX, sumRoundsX := 0.0, 0.0
+			for _, chance := range damageA[1:] {
+				if hB-chance.damage <= 0 {
+					sumWinX += chance.probability
+				} else {
+					sumWinX += chance.probability * winY[hA][hB-chance.damage]
+					sumRoundsX += chance.probability * roundsY[hA][hB-chance.damage]
+				}
+			}
+
+			// sumY: B attacks and deals damage, then it is A's turn with lower hA (already computed).
+			sumWinY, sumRoundsY := 0.0, 0.0
+			for _, chance := range damageB[1:] {
+				if hA-chance.damage > 0 {
+					sumWinY += chance.probability * winX[hA-chance.damage][hB]
+					sumRoundsY += chance.probability * roundsX[hA-chance.damage][hB]
+				}
+			}
+
+			// A zero-damage round hands the turn over without changing health, so
+			// X = sumX + zeroA*Y and Y = sumY + zeroB*X are solved together.
+			denominator := 1 - zeroA*zeroB
+			winX[hA][hB] = (sumWinX + zeroA*sumWinY) / denominator
+			winY[hA][hB] = sumWinY + zeroB*winX[hA][hB]
+			roundsX[hA][hB] = (1 + sumRoundsX + zeroA*(1+sumRoundsY)) / denominator
+			roundsY[hA][hB] = 1 + sumRoundsY + zeroB*roundsX[hA][hB]
+		}
+	}
+
+	//weighing the odds of A starting and B starting by the chance of each
+	startA := 0.5
+	if rules.StartingPlayer != StartRandom {
+		startA = 0
+		if determineStartingPlayer(&Match{PlayerA: playerA, PlayerB: playerB, settings: settings{rules: rules}}) == playerA {
+			startA = 1
+		}
+	}
+	probability := Probability{
+		WinA:           startA*winX[healthA][healthB] + (1-startA)*winY[healthA][healthB],
+		ExpectedRounds: startA*roundsX[healthA][healthB] + (1-startA)*roundsY[healthA][healthB],
+	}
+	probability.WinA = math.Min(1, math.Max(0, probability.WinA))
+	probability.WinB = 1 - probability.WinA
+	return probability
+}
+
+// damageChance is the probability of a single attack dealing a given amount of damage.
+type damageChance struct {
+	damage      int
+	probability float64
+}
+
+// damageDistribution returns the probability of every possible damage value of a single attack.
+//
+// Parameters:
+//   - rules: The rules giving the number of dice faces and the damage floor.
+//   - attack: The attack attribute of the attacking player.
+//   - strength: The strength attribute of the defending player.
+//
+// Returns:
+//   - []damageChance: The chance of each damage value in increasing order of damage. The first entry is always 0 damage.
+func damageDistribution(rules Rules, attack, strength int) []damageChance {
+	//counting the dice combinations that lead to each damage value
+	faces := rules.DiceFaces
+	counts := map[int]int{0: 0}
+	for attackRoll := 1; attackRoll <= faces; attackRoll++ {
+		for defenceRoll := 1; defenceRoll <= faces; defenceRoll++ {
+			counts[max(rules.DamageFloor, attack*attackRoll-strength*defenceRoll)]++
+		}
+	}
+
+	distribution := make([]damageChance, 0, len(counts))
+	for damage, count := range counts {
+		distribution = append(distribution, damageChance{damage, float64(count) / float64(faces*faces)})
+	}
+	sort.Slice(distribution, func(i, j int) bool {
+		return distribution[i].damage < distribution[j].damage
+	})
+	return distribution
+}
+
+// newTable allocates a (healthA+1) x (healthB+1) table of probabilities.
+func newTable(healthA, healthB int) [][]float64 {
+	table := make([][]float64, healthA+1)
+	for i := range table {
+		table[i] = make([]float64, healthB+1)
+	}
+	return table
+}
@@ -0,0 +1,183 @@
+package match
+
+import (
+	"errors"
+	"fmt"
+	"magical-arena/pkg/player"
+)
+
+// ErrReplayMismatch is returned by Replay when re-executing a replay does not reproduce the recorded match.
+var ErrReplayMismatch = errors.New("replay does not match the recorded match")
+
+// ReplayPlayer holds the identity and starting attributes of a player in a replay.
+type ReplayPlayer struct {
+	// ID is the unique ID of the player.
+	ID string `json:"id"`
+
+	// Name is the name of the player.
+	Name string `json:"name"`
+
+	// Health is the starting health of the player.
+	Health int `json:"health"`
+
+	// Strength is the strength attribute of the player.
+	Strength int `json:"strength"`
+
+	// Attack is the attack attribute of the player.
+	Attack int `json:"attack"`
+}
+
+// ReplayLog is everything needed to re-execute a two-player match exactly: the players, the starting player,
+// the rules, the round limits and every die roll, together with the recorded rounds and result to verify against.
+// It is designed to be stored as JSON.
+type ReplayLog struct {
+	// PlayerA is Player A of the match.
+	PlayerA ReplayPlayer `json:"playerA"`
+
+	// PlayerB is Player B of the match.
+	PlayerB ReplayPlayer `json:"playerB"`
+
+	// StartingPlayerID is the ID of the player who attacked in the first round.
+	StartingPlayerID string `json:"startingPlayerId"`
+
+	// Rules are the rules the match was played by. Replays recorded without rules are replayed with DefaultRules.
+	Rules *Rules `json:"rules,omitempty"`
+
+	// MaxRounds is the round limit of the match, 0 for no limit.
+	MaxRounds int `json:"maxRounds"`
+
+	// StalemateRounds is the number of consecutive zero-damage rounds that ended the match in a Stalemate, 0 when disabled.
+	StalemateRounds int `json:"stalemateRounds"`
+
+	// Rolls holds every die roll of the match in the order they were rolled: attack roll, then defence roll, for every round.
+	Rolls []int `json:"rolls"`
+
+	// Rounds holds the recorded event of every round.
+	Rounds []RoundEvent `json:"rounds"`
+
+	// Outcome is the recorded outcome of the match.
+	Outcome Outcome `json:"outcome"`
+
+	// WinnerID is the ID of the recorded winner, or empty when the match was not won.
+	WinnerID string `json:"winnerId,omitempty"`
+}
+
+// replayPlayer captures a player for a replay.
+func replayPlayer(p *player.Player) ReplayPlayer {
+	name, health, strength, attack := player.GetPlayerBaseAttributes(p)
+	return ReplayPlayer{ID: player.GetPlayerID(p), Name: name, Health: health, Strength: strength, Attack: attack}
+}
+
+// newPlayer restores the player of a replay.
+func (p ReplayPlayer) newPlayer() *player.Player {
+	return player.NewPlayerWithID(p.ID, p.Name, p.Health, p.Strength, p.Attack)
+}
+
+// RecordReplay captures a match played so far as a ReplayLog. It is usually called once the match is over.
+//
+// Parameters:
+//   - m: A pointer to the match to capture.
+//
+// Returns:
+//   - ReplayLog: The replay of the match.
+//
+// Example:
+//   ConductMatch(myMatch)
+//   data, err := json.Marshal(RecordReplay(myMatch))
+func RecordReplay(m *Match) ReplayLog {
+	rules := m.rules
+	replay := ReplayLog{
+		PlayerA:         replayPlayer(m.PlayerA),
+		PlayerB:         replayPlayer(m.PlayerB),
+		Rules:           &rules,
+		MaxRounds:       m.maxRounds,
+		StalemateRounds: m.stalemateRounds,
+		Rolls:           make([]int, 0, 2*len(m.roundEvents)),
+		Rounds:          append([]RoundEvent{}, m.roundEvents...),
+		Outcome:         m.result.Outcome,
+	}
+
+	//the first attacker is the one of the first round, or the one who would have attacked in a match with no rounds
+	replay.StartingPlayerID = player.GetPlayerID(m.currentPlayer)
+	if len(m.roundEvents) > 0 {
+		replay.StartingPlayerID = m.roundEvents[0].AttackerID
+	}
+	for _, event := range m.roundEvents {
+		replay.Rolls = append(replay.Rolls, event.AttackRoll, event.DefenceRoll)
+	}
+	if m.result.Winner != nil {
+		replay.WinnerID = player.GetPlayerID(m.result.Winner)
+	}
+	return replay
+}
+
+// Replay re-executes a recorded match with its recorded die rolls, and verifies that every round and the
+// final result come out exactly as recorded. A match recorded as Aborted is aborted again after its recorded rounds.
+//
+// Parameters:
+//   - replay: The replay to re-execute.
+//
+// Returns:
+//   - Result: The result of the re-executed match.
+//   - error: An error wrapping ErrReplayMismatch describing the first difference, or nil if the match was reproduced.
+//
+// Example:
+//   var replay ReplayLog
+//   json.Unmarshal(data, &replay)
+//   if _, err := Replay(replay); err != nil {
+//       fmt.Println(err)
+//   }
+func Replay(replay ReplayLog) (Result, error) {
+	playerA, playerB := replay.PlayerA.newPlayer(), replay.PlayerB.newPlayer()
+	var firstAttacker *player.Player
+	switch replay.StartingPlayerID {
+	case replay.PlayerA.ID:
+		firstAttacker = playerA
+	case replay.PlayerB.ID:
+		firstAttacker = playerB
+	default:
+		return Result{}, fmt.Errorf("%w: starting player %q is not in the match", ErrReplayMismatch, replay.StartingPlayerID)
+	}
+
+	rules := DefaultRules()
+	if replay.Rules != nil {
+		rules = *replay.Rules
+	}
+	dice := NewScriptedDice(replay.Rolls...)
+	m := NewMatch(playerA, playerB, WithRules(rules), WithDice(dice), WithFirstAttacker(firstAttacker),
+		WithMaxRounds(replay.MaxRounds), WithStalemateRounds(replay.StalemateRounds))
+
+	for !m.IsOver() {
+		if len(m.roundEvents) >= len(replay.Rounds) {
+			//an aborted match stops at the recorded round count instead of playing on
+			if replay.Outcome == Aborted {
+				m.Abort()
+				break
+			}
+			return m.Result(), fmt.Errorf("%w: the match did not end after the %d recorded rounds", ErrReplayMismatch, len(replay.Rounds))
+		}
+		if 2*(len(m.roundEvents)+1) > len(replay.Rolls) {
+			return m.Result(), fmt.Errorf("%w: ran out of rolls in round %d", ErrReplayMismatch, len(m.roundEvents)+1)
+		}
+		event, _ := m.NextRound()
+		if recorded := replay.Rounds[event.Round-1]; event != recorded {
+			return m.Result(), fmt.Errorf("%w: round %d was %+v, recorded %+v", ErrReplayMismatch, event.Round, event, recorded)
+		}
+	}
+
+	result := m.Result()
+	winnerID := ""
+	if result.Winner != nil {
+		winnerID = player.GetPlayerID(result.Winner)
+	}
+	switch {
+	case result.Rounds != len(replay.Rounds):
+		return result, fmt.Errorf("%w: the match ended after %d rounds, recorded %d", ErrReplayMismatch, result.Rounds, len(replay.Rounds))
+	case result.Outcome != replay.Outcome || winnerID != replay.WinnerID:
+		return result, fmt.Errorf("%w: the match ended in %v (winner %q), recorded %v (winner %q)",
+			ErrReplayMismatch, result.Outcome, winnerID, replay.Outcome, replay.WinnerID)
+	case len(replay.Rolls) != 2*result.Rounds:
+		return result, fmt.Errorf("%w: %d rolls were recorded for %d rounds", ErrReplayMismatch, len(replay.Rolls), result.Rounds)
+	}
+	return result, nil
+}
@@ -0,0 +1,237 @@
+package match
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"magical-arena/pkg/player"
+	"os"
+)
+
+// ErrInvalidRules is returned when a rules configuration is malformed or out of range.
+var ErrInvalidRules = errors.New("invalid rules")
+
+// StartingPolicy decides which player attacks first in a two-player match.
+type StartingPolicy string
+
+const (
+	// StartLowerHealth makes the player with lower health attack first, Player A on a tie. It is the default.
+	StartLowerHealth StartingPolicy = "lower-health"
+
+	// StartHigherHealth makes the player with higher health attack first, Player A on a tie.
+	StartHigherHealth StartingPolicy = "higher-health"
+
+	// StartPlayerA makes Player A attack first.
+	StartPlayerA StartingPolicy = "player-a"
+
+	// StartRandom makes a coin flip, rolled with the match dice, decide who attacks first.
+	StartRandom StartingPolicy = "random"
+)
+
+// Rules are the game rules of a match: the dice, who starts, how damage is computed, when a match ends
+// and which players are allowed to fight. DefaultRules returns the standard rules of the arena.
+// Rules are designed to be stored as JSON; see LoadRules.
+type Rules struct {
+	// DiceFaces is the number of faces of the die rolled for every attack and defence.
+	DiceFaces int `json:"diceFaces"`
+
+	// StartingPlayer decides who attacks first in a two-player match.
+	StartingPlayer StartingPolicy `json:"startingPlayer"`
+
+	// DamageFloor is the least damage an attack deals, however strong the defence.
+	DamageFloor int `json:"damageFloor"`
+
+	// MaxRounds is the maximum number of rounds before the match ends in a Draw, 0 for no limit.
+	MaxRounds int `json:"maxRounds"`
+
+	// StalemateRounds is the number of consecutive zero-damage rounds before the match ends in a Stalemate, 0 to disable.
+	StalemateRounds int `json:"stalemateRounds"`
+
+	// MinHealth is the least health a player needs to fight.
+	MinHealth int `json:"minHealth"`
+
+	// MinStrength is the least strength a player needs to fight.
+	MinStrength int `json:"minStrength"`
+
+	// MinAttack is the least attack a player needs to fight.
+	MinAttack int `json:"minAttack"`
+
+	// RequireDamage requires each player's best attack roll to beat the other player's worst defence roll,
+	// that is attack*DiceFaces > strength, so that both players can deal damage.
+	RequireDamage bool `json:"requireDamage"`
+}
+
+// DefaultRules returns the standard rules of the arena: a six-sided die, the player with lower health
+// attacks first, damage never goes below zero, no round limit, DefaultStalemateRounds zero-damage rounds
+// end the match, and players need positive attributes and must be able to damage each other.
+//
+// Returns:
+//   - Rules: The default rules.
+//
+// Example:
+//   rules := DefaultRules()
+//   rules.MaxRounds = 200
+//   match := NewMatch(player1, player2, WithRules(rules))
+func DefaultRules() Rules {
+	return Rules{
+		DiceFaces:       diceFaces,
+		StartingPlayer:  StartLowerHealth,
+		DamageFloor:     0,
+		MaxRounds:       0,
+		StalemateRounds: DefaultStalemateRounds,
+		MinHealth:       1,
+		MinStrength:     1,
+		MinAttack:       1,
+		RequireDamage:   true,
+	}
+}
+
+// DecodeRules reads rules in JSON from a reader. Fields missing from the JSON keep their default value,
+// and unknown fields are rejected so that typos do not go unnoticed.
+//
+// Parameters:
+//   - r: The reader to decode from.
+//
+// Returns:
+//   - Rules: The decoded rules.
+//   - error: An error wrapping ErrInvalidRules if the rules cannot be decoded or are invalid.
+//
+// Example:
+//   rules, err := DecodeRules(strings.NewReader(`{"diceFaces": 20}`))
+func DecodeRules(r io.Reader) (Rules, error) {
+	rules := DefaultRules()
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&rules); err != nil {
+		return Rules{}, fmt.Errorf("%w: %v", ErrInvalidRules, err)
+	}
+	if err := rules.Validate(); err != nil {
+		return Rules{}, err
+	}
+	return rules, nil
+}
+
+// LoadRules reads rules from a JSON file, as described by DecodeRules.
+//
+// Parameters:
+//   - path: The path of the rules file.
+//
+// Returns:
+//   - Rules: The loaded rules.
+//   - error: An error if the file cannot be read, or an error wrapping ErrInvalidRules if the rules are invalid.
+//
+// Example:
+//   rules, err := LoadRules("rules.json")
+func LoadRules(path string) (Rules, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Rules{}, err
+	}
+	defer file.Close()
+
+	rules, err := DecodeRules(file)
+	if err != nil {
+		return Rules{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// Validate checks that the rules are usable. Every match must be able to end, so rules that disable the
+// round limit, the stalemate limit, the damage floor and the damage requirement all at once are rejected.
+//
+// Returns:
+//   - error: An error wrapping ErrInvalidRules describing the first invalid setting, or nil.
+func (r Rules) Validate() error {
+	switch {
+	case r.DiceFaces < 1:
+		return fmt.Errorf("%w: diceFaces must be at least 1", ErrInvalidRules)
+	case r.DamageFloor < 0:
+		return fmt.Errorf("%w: damageFloor must not be negative", ErrInvalidRules)
+	case r.MaxRounds < 0:
+		return fmt.Errorf("%w: maxRounds must not be negative", ErrInvalidRules)
+	case r.StalemateRounds < 0:
+		return fmt.Errorf("%w: stalemateRounds must not be negative", ErrInvalidRules)
+	case r.StalemateRounds == 0 && r.MaxRounds == 0 && r.DamageFloor == 0 && !r.RequireDamage:
+		//players that cannot damage each other would fight forever
+		return fmt.Errorf("%w: a match must be able to end: set maxRounds, stalemateRounds, damageFloor or requireDamage", ErrInvalidRules)
+	}
+	switch r.StartingPlayer {
+	case StartLowerHealth, StartHigherHealth, StartPlayerA, StartRandom:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown startingPlayer %q", ErrInvalidRules, r.StartingPlayer)
+	}
+}
+
+// ValidatePlayers checks that two players are allowed to fight each other under the rules.
+// A player, or two copies of a player with the same ID, cannot fight themselves.
+//
+// Parameters:
+//   - player1: A pointer to Player 1.
+//   - player2: A pointer to Player 2.
+//
+// Returns:
+//   - error: An error describing why the players cannot fight, or nil if they can.
+//
+// Example:
+//   if err := DefaultRules().ValidatePlayers(player1, player2); err != nil {
+//       fmt.Println(err)
+//   }
+func (r Rules) ValidatePlayers(player1, player2 *player.Player) error {
+	if samePlayer(player1, player2) {
+		return errors.New("Player 1 and Player 2 must be different players.")
+	}
+
+	_, playerHealth1, playerStrength1, playerAttack1 := player.GetPlayerBaseAttributes(player1)
+	_, playerHealth2, playerStrength2, playerAttack2 := player.GetPlayerBaseAttributes(player2)
+
+	//check for the minimum health, strength and attack
+	if playerHealth1 < r.MinHealth || playerHealth2 < r.MinHealth {
+		return fmt.Errorf("Player health must be greater than %d.", r.MinHealth-1)
+	}
+	if playerStrength1 < r.MinStrength || playerStrength2 < r.MinStrength {
+		return fmt.Errorf("Player strength must be greater than %d.", r.MinStrength-1)
+	}
+	if playerAttack1 < r.MinAttack || playerAttack2 < r.MinAttack {
+		return fmt.Errorf("Player attack must be greater than %d.", r.MinAttack-1)
+	}
+
+	//check that the best attack roll of each player beats the worst defence roll of the other
+	if r.RequireDamage && playerAttack1*r.DiceFaces <= playerStrength2 {
+		return errors.New("Player 1 attack is too low to damage Player 2.")
+	}
+	if r.RequireDamage && playerAttack2*r.DiceFaces <= playerStrength1 {
+		return errors.New("Player 2 attack is too low to damage Player 1.")
+	}
+	return nil
+}
+
+// WithRules makes the match play by the given rules: dice faces, starting player, damage floor and
+// round limits. WithMaxRounds and WithStalemateRounds given after WithRules override its round limits.
+//
+// Parameters:
+//   - rules: The rules of the match.
+//
+// Returns:
+//   - Option: An option to pass to NewMatch.
+//
+// Example:
+//   rules, _ := LoadRules("rules.json")
+//   match := NewMatch(player1, player2, WithRules(rules))
+func WithRules(rules Rules) Option {
+	return func(s *settings) {
+		s.rules = rules
+		s.maxRounds = rules.MaxRounds
+		s.stalemateRounds = rules.StalemateRounds
+	}
+}
+
+// samePlayer reports whether two players are the same player: the same pointer, or two copies with the same ID.
+func samePlayer(player1, player2 *player.Player) bool {
+	if player1 == player2 {
+		return true
+	}
+	id := player.GetPlayerID(player1)
+	return id != "" && id == player.GetPlayerID(player2)
+}
@@ -0,0 +1,135 @@
+package match
+
+import "magical-arena/pkg/player"
+
+// Fighter is the live state of a player in a match with more than two players.
+type Fighter struct {
+	// Player is a pointer to the fighting player.
+	Player *player.Player
+
+	// Health is the current health of the player.
+	Health int
+
+	// LastAttacker is the player who most recently attacked this fighter, or nil if nobody has yet.
+	LastAttacker *player.Player
+}
+
+// isAlive reports whether the fighter is still in the match.
+func (f *Fighter) isAlive() bool {
+	return f.Health > 0
+}
+
+// TargetingPolicy chooses which opponent an attacker hits in a match with more than two players.
+type TargetingPolicy interface {
+	// ChooseTarget returns one of the candidates, all of whom are alive opponents of the attacker.
+	// The match dice may be used for random choices so that matches stay reproducible.
+	// Returning anything else aborts the match with ErrInvalidTarget.
+	// The attacker and the candidates are copies of the state of the match, so changing them has no effect on it.
+	ChooseTarget(dice Dice, attacker *Fighter, candidates []*Fighter) *Fighter
+}
+
+// TargetingPolicyFunc adapts an ordinary function to the TargetingPolicy interface.
+type TargetingPolicyFunc func(dice Dice, attacker *Fighter, candidates []*Fighter) *Fighter
+
+// ChooseTarget calls f(dice, attacker, candidates).
+func (f TargetingPolicyFunc) ChooseTarget(dice Dice, attacker *Fighter, candidates []*Fighter) *Fighter {
+	return f(dice, attacker, candidates)
+}
+
+// chooseTarget asks a TargetingPolicy to choose among copies of the attacker and the candidates, so that the policy
+// cannot change the live state of the match, and returns the live candidate it chose.
+//
+// Parameters:
+//   - policy: The TargetingPolicy choosing the target.
+//   - dice: The match dice, for random choices.
+//   - attacker: The live attacking fighter.
+//   - candidates: The live fighters that may be attacked.
+//
+// Returns:
+//   - *Fighter: The live candidate chosen by the policy.
+//   - bool: false if the policy chose a fighter that is not one of the candidates.
+func chooseTarget(policy TargetingPolicy, dice Dice, attacker *Fighter, candidates []*Fighter) (*Fighter, bool) {
+	attackerCopy := *attacker
+	copies := make([]*Fighter, len(candidates))
+	live := make(map[*Fighter]*Fighter, len(candidates))
+	for i, candidate := range candidates {
+		candidateCopy := *candidate
+		copies[i] = &candidateCopy
+		live[copies[i]] = candidate
+	}
+	target, ok := live[policy.ChooseTarget(dice, &attackerCopy, copies)]
+	return target, ok
+}
+
+// RandomTarget picks a candidate uniformly at random, using the match dice.
+var RandomTarget TargetingPolicy = TargetingPolicyFunc(randomTarget)
+
+// WeakestTarget picks the candidate with the lowest current health, the earliest in turn order on ties.
+var WeakestTarget TargetingPolicy = TargetingPolicyFunc(weakestTarget)
+
+// StrongestTarget picks the candidate with the highest current health, the earliest in turn order on ties.
+var StrongestTarget TargetingPolicy = TargetingPolicyFunc(strongestTarget)
+
+// LastAttackerTarget strikes back at whoever last attacked the attacker. If that player is not a candidate
+// (nobody has attacked yet, or they are already out), it picks a candidate at random.
+var LastAttackerTarget TargetingPolicy = TargetingPolicyFunc(lastAttackerTarget)
+
+// randomTarget implements RandomTarget. Rolls outside [1, len(candidates)], which scripted dice
+// may return, are wrapped around into range.
+func randomTarget(dice Dice, attacker *Fighter, candidates []*Fighter) *Fighter {
+	index := (dice.Roll(len(candidates)) - 1) % len(candidates)
+	if index < 0 {
+		index += len(candidates)
+	}
+	return candidates[index]
+}
+
+// weakestTarget implements WeakestTarget.
+func weakestTarget(dice Dice, attacker *Fighter, candidates []*Fighter) *Fighter {
+	target := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if candidate.Health < target.Health {
+			target = candidate
+		}
+	}
+	return target
+}
+
+// strongestTarget implements StrongestTarget.
+func strongestTarget(dice Dice, attacker *Fighter, candidates []*Fighter) *Fighter {
+	target := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if candidate.Health > target.Health {
+			target = candidate
+		}
+	}
+	return target
+}
+
+// lastAttackerTarget implements LastAttackerTarget.
+func lastAttackerTarget(dice Dice, attacker *Fighter, candidates []*Fighter) *Fighter {
+	for _, candidate := range candidates {
+		if candidate.Player == attacker.LastAttacker {
+			return candidate
+		}
+	}
+	return randomTarget(dice, attacker, candidates)
+}
+
+// strike makes attacker attack target, applying the damage and recording the attacker on the target.
+//
+// Parameters:
+//   - dice: The Dice used to roll attack and defence.
+//   - rules: The rules deciding the dice faces and the damage floor.
+//   - round: The 1-based number of the round.
+//   - attacker: The attacking fighter.
+//   - target: The defending fighter.
+//
+// Returns:
+//   - RoundEvent: The event describing the attack.
+func strike(dice Dice, rules Rules, round int, attacker, target *Fighter) RoundEvent {
+	roundEvent := attack(dice, rules, round, attacker.Player, attacker.Health, target.Player, target.Health)
+	target.Health = roundEvent.DefenderHealth
+	target.LastAttacker = attacker.Player
+	return roundEvent
+}
@@ -0,0 +1,296 @@
+package match
+
+import (
+	"fmt"
+	"magical-arena/pkg/player"
+)
+
+// Team is a named group of players fighting together in a TeamMatch.
+type Team struct {
+	// Name is the name of the team.
+	Name string
+
+	// Members are the players in the team.
+	Members []*player.Player
+}
+
+// MemberStats records how a single player fared in a TeamMatch.
+type MemberStats struct {
+	// Player is a pointer to the team member.
+	Player *player.Player
+
+	// Team is the index of the member's team.
+	Team int
+
+	// Health is the member's current health.
+	Health int
+
+	// DamageDealt is the total damage the member dealt to opponents.
+	DamageDealt int
+
+	// DamageTaken is the total damage the member received.
+	DamageTaken int
+}
+
+// TeamResult is the team-level result of a TeamMatch.
+type TeamResult struct {
+	// Outcome is the kind of result the match ended with.
+	Outcome Outcome
+
+	// Winner is the index of the winning team when Outcome is Win, and -1 otherwise.
+	Winner int
+
+	// WinnerName is the name of the winning team when Outcome is Win, and empty otherwise.
+	WinnerName string
+
+	// Rounds is the number of rounds played.
+	Rounds int
+
+	// Members holds the stats of every player, in turn order.
+	Members []MemberStats
+}
+
+// String returns the text form of the result, e.g. "Red wins" or "Draw after 200 rounds".
+func (r TeamResult) String() string {
+	if r.Outcome == Win {
+		return fmt.Sprintf("%s wins", r.WinnerName)
+	}
+	return Result{Outcome: r.Outcome, Rounds: r.Rounds}.String()
+}
+
+// teamFighter is the live state of a team member.
+type teamFighter struct {
+	Fighter
+
+	// stats is the running tally of the member.
+	stats MemberStats
+}
+
+// TeamMatch is a match between two or more teams. Turns are interleaved across teams (the first member of every team,
+// then the second member of every team, and so on), each attacker hits a member of an opposing team chosen by
+// the match's TargetingPolicy, and the last team with a member standing wins.
+type TeamMatch struct {
+	// teams are the teams in the match.
+	teams []Team
+
+	// order holds every team member, in turn order.
+	order []*teamFighter
+
+	// policy chooses the target of every attack.
+	policy TargetingPolicy
+
+	// next is the index in order of the member who attacks in the next round.
+	next int
+
+	// roundEvents stores the events of each round in the match.
+	roundEvents []RoundEvent
+
+	// result indicates the overall result of the match.
+	result TeamResult
+
+	// settings holds the dice, seed and round limits of the match.
+	settings
+
+	// round is the number of rounds played so far.
+	round int
+
+	// zeroDamageRounds is the number of consecutive rounds in which no damage was dealt.
+	zeroDamageRounds int
+}
+
+// NewTeamMatch creates a match between the given teams.
+//
+// Parameters:
+//   - teams: The teams in the match. The first team's first member attacks first.
+//   - policy: The TargetingPolicy choosing which opponent each attacker hits, such as RandomTarget or WeakestTarget.
+//   - opts: Optional settings such as WithSeed, WithDice, WithMaxRounds, WithStalemateRounds or WithRules.
+//     The starting player policy of the rules does not apply.
+//
+// Returns:
+//   - *TeamMatch: A pointer to the newly created match.
+//   - error: ErrTooFewPlayers if fewer than two teams have members, ErrNoTargetingPolicy if policy is nil.
+//
+// Example:
+//   red := Team{Name: "Red", Members: []*player.Player{player1, player2}}
+//   blue := Team{Name: "Blue", Members: []*player.Player{player3, player4}}
+//   teamMatch, err := NewTeamMatch([]Team{red, blue}, RandomTarget, WithSeed(42))
+func NewTeamMatch(teams []Team, policy TargetingPolicy, opts ...Option) (*TeamMatch, error) {
+	nonEmpty := 0
+	for _, team := range teams {
+		if len(team.Members) > 0 {
+			nonEmpty++
+		}
+	}
+	if nonEmpty < 2 {
+		return nil, ErrTooFewPlayers
+	}
+	if policy == nil {
+		return nil, ErrNoTargetingPolicy
+	}
+
+	tm := &TeamMatch{teams: teams, policy: policy, roundEvents: []RoundEvent{}, settings: newSettings(opts)}
+	tm.result.Winner = -1
+
+	//interleaving the members of all teams into a single turn order
+	for slot := 0; len(tm.order) < countMembers(teams); slot++ {
+		for t, team := range teams {
+			if slot < len(team.Members) {
+				member := team.Members[slot]
+				_, health, _, _ := player.GetPlayerBaseAttributes(member)
+				tm.order = append(tm.order, &teamFighter{
+					Fighter: Fighter{Player: member, Health: health},
+					stats:   MemberStats{Player: member, Team: t, Health: health},
+				})
+			}
+		}
+	}
+
+	//members who enter with no health are out before the first round
+	tm.next = len(tm.order) - 1
+	tm.advance()
+	tm.checkLastTeamStanding()
+	return tm, nil
+}
+
+// countMembers returns the total number of players across all teams.
+func countMembers(teams []Team) int {
+	count := 0
+	for _, team := range teams {
+		count += len(team.Members)
+	}
+	return count
+}
+
+// Seed returns the seed the match dice were created from.
+func (tm *TeamMatch) Seed() int64 {
+	return tm.seed
+}
+
+// IsOver reports whether the match has finished.
+func (tm *TeamMatch) IsOver() bool {
+	return tm.result.Outcome != Undecided
+}
+
+// RoundEvents returns the events of the rounds played so far.
+func (tm *TeamMatch) RoundEvents() []RoundEvent {
+	return tm.roundEvents
+}
+
+// Result returns the result of the match, including the current stats of every member.
+// Its Outcome is Undecided while the match is in progress.
+func (tm *TeamMatch) Result() TeamResult {
+	result := tm.result
+	result.Members = make([]MemberStats, len(tm.order))
+	for i, member := range tm.order {
+		result.Members[i] = member.stats
+	}
+	return result
+}
+
+// NextRound plays a single round: the next member alive attacks a member of an opposing team chosen by
+// the TargetingPolicy, and the turn passes to the next member alive. If the policy chooses a target that is not
+// one of the candidates, no round is played and the match is aborted.
+//
+// Returns:
+//   - RoundEvent: The event describing the round that was played.
+//   - error: ErrMatchOver if the match had already finished, ErrInvalidTarget if the policy chose an invalid target,
+//     nil otherwise.
+func (tm *TeamMatch) NextRound() (RoundEvent, error) {
+	if tm.IsOver() {
+		return RoundEvent{}, ErrMatchOver
+	}
+
+	//choosing a target among the opposing members still alive
+	attacker := tm.order[tm.next]
+	var candidates []*Fighter
+	targets := make(map[*Fighter]*teamFighter)
+	for _, member := range tm.order {
+		if member.stats.Team != attacker.stats.Team && member.isAlive() {
+			candidates = append(candidates, &member.Fighter)
+			targets[&member.Fighter] = member
+		}
+	}
+	chosen, ok := chooseTarget(tm.policy, tm.dice, &attacker.Fighter, candidates)
+	if !ok {
+		tm.result.Outcome, tm.result.Rounds = Aborted, tm.round
+		return RoundEvent{}, ErrInvalidTarget
+	}
+
+	target := targets[chosen]
+
+	//conducting the round
+	tm.round++
+	roundEvent := strike(tm.dice, tm.rules, tm.round, &attacker.Fighter, &target.Fighter)
+	tm.roundEvents = append(tm.roundEvents, roundEvent)
+	attacker.stats.DamageDealt += roundEvent.Damage
+	target.stats.DamageTaken += roundEvent.Damage
+	target.stats.Health = target.Health
+	tm.advance()
+
+	//counting consecutive rounds without damage for the stalemate rule
+	if roundEvent.Damage == 0 {
+		tm.zeroDamageRounds++
+	} else {
+		tm.zeroDamageRounds = 0
+	}
+
+	if !tm.checkLastTeamStanding() {
+		if outcome := tm.limitOutcome(tm.round, tm.zeroDamageRounds); outcome != Undecided {
+			tm.result.Outcome, tm.result.Rounds = outcome, tm.round
+		}
+	}
+	return roundEvent, nil
+}
+
+// advance passes the turn to the next member alive after the current one.
+func (tm *TeamMatch) advance() {
+	for i := 1; i <= len(tm.order); i++ {
+		next := (tm.next + i) % len(tm.order)
+		if tm.order[next].isAlive() {
+			tm.next = next
+			return
+		}
+	}
+}
+
+// checkLastTeamStanding records the result of the match once at most one team has members alive.
+//
+// Returns:
+//   - bool: true if the match is over, false otherwise.
+func (tm *TeamMatch) checkLastTeamStanding() bool {
+	survivor := -1
+	for _, member := range tm.order {
+		if member.isAlive() && member.stats.Team != survivor {
+			if survivor >= 0 {
+				return false
+			}
+			survivor = member.stats.Team
+		}
+	}
+	tm.result.Rounds = tm.round
+	if survivor < 0 {
+		tm.result.Outcome = Draw
+	} else {
+		tm.result.Outcome, tm.result.Winner, tm.result.WinnerName = Win, survivor, tm.teams[survivor].Name
+	}
+	return true
+}
+
+// ConductTeamMatch plays a team match until a single team remains, a round limit ends it or it is aborted.
+//
+// Parameters:
+//   - tm: A pointer to the TeamMatch to play.
+//
+// Returns:
+//   - []RoundEvent: A slice containing the event of each round.
+//   - TeamResult: The team-level result of the match, with per-member damage dealt and taken.
+//
+// Example:
+//   roundEvents, result := ConductTeamMatch(teamMatch)
+//   fmt.Println("Match Result:", result)
+func ConductTeamMatch(tm *TeamMatch) ([]RoundEvent, TeamResult) {
+	for !tm.IsOver() {
+		tm.NextRound()
+	}
+	return tm.roundEvents, tm.Result()
+}
@@ -0,0 +1,73 @@
+package match
+
+import (
+	"fmt"
+	"magical-arena/pkg/player"
+	"testing"
+)
+
+// TestTeamMatch tests matches between teams of players.
+func TestTeamMatch(t *testing.T) {
+	// TEST 1: a single team cannot fight
+	solo := Team{Name: "Solo", Members: []*player.Player{player.NewPlayer("A", 10, 1, 1), player.NewPlayer("B", 10, 1, 1)}}
+	_, err := NewTeamMatch([]Team{solo, {Name: "Empty"}}, RandomTarget)
+	if err != ErrTooFewPlayers {
+		t.Errorf(redColor+"Expected ErrTooFewPlayers, got %v"+resetColor, err)
+	} else {
+		fmt.Println(greenColor + "TestTeamMatch : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: turns interleave across teams and attackers only target opponents.
+	// Every die rolls 4, so each hit deals 4*10 - 4*5 = 20 damage.
+	red1 := player.NewPlayer("Red1", 40, 5, 10)
+	red2 := player.NewPlayer("Red2", 40, 5, 10)
+	blue1 := player.NewPlayer("Blue1", 40, 5, 10)
+	blue2 := player.NewPlayer("Blue2", 40, 5, 10)
+	red := Team{Name: "Red", Members: []*player.Player{red1, red2}}
+	blue := Team{Name: "Blue", Members: []*player.Player{blue1, blue2}}
+	tm, _ := NewTeamMatch([]Team{red, blue}, WeakestTarget, WithDice(NewScriptedDice(4)))
+	var attackers []string
+	for i := 0; i < 4; i++ {
+		event, _ := tm.NextRound()
+		attackers = append(attackers, event.Attacker)
+		if (event.Attacker[0] == 'R') == (event.Defender[0] == 'R') {
+			t.Errorf(redColor+"Expected %s to attack an opponent, got %s"+resetColor, event.Attacker, event.Defender)
+		}
+	}
+	if fmt.Sprint(attackers) != "[Red1 Blue1 Red2 Blue2]" {
+		t.Errorf(redColor+"Expected interleaved turns, got %v"+resetColor, attackers)
+	} else {
+		fmt.Println(greenColor + "TestTeamMatch : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: Red strikes first, so Red wins after 7 hits of 20; damage dealt and taken balance across all members
+	_, result := ConductTeamMatch(tm)
+	dealt, taken := 0, 0
+	for _, member := range result.Members {
+		dealt += member.DamageDealt
+		taken += member.DamageTaken
+		if member.Team == 1 && member.Health != 0 {
+			t.Errorf(redColor+"Expected every Blue member to be out, got %+v"+resetColor, member)
+		}
+	}
+	if result.Outcome != Win || result.Winner != 0 || result.String() != "Red wins" || dealt != taken || dealt != 140 {
+		t.Errorf(redColor+"Expected Red to win after 140 damage, got %s (dealt %d, taken %d)"+resetColor, result, dealt, taken)
+	} else {
+		fmt.Println(greenColor + "TestTeamMatch : Test3 : Passed" + resetColor)
+	}
+
+	// TEST 4: a nil policy is rejected, and a policy choosing an invalid target aborts the match instead of panicking
+	redTeam := Team{Name: "Red", Members: []*player.Player{player.NewPlayer("Red1", 100, 5, 10)}}
+	blueTeam := Team{Name: "Blue", Members: []*player.Player{player.NewPlayer("Blue1", 100, 5, 10)}}
+	_, nilErr := NewTeamMatch([]Team{redTeam, blueTeam}, nil)
+	outside := TargetingPolicyFunc(func(dice Dice, attacker *Fighter, candidates []*Fighter) *Fighter {
+		return attacker
+	})
+	tm, _ = NewTeamMatch([]Team{redTeam, blueTeam}, outside)
+	_, err = tm.NextRound()
+	if nilErr != ErrNoTargetingPolicy || err != ErrInvalidTarget || tm.Result().Outcome != Aborted || len(tm.RoundEvents()) != 0 {
+		t.Errorf(redColor+"Expected ErrNoTargetingPolicy and an aborted match, got %v, %v, %s"+resetColor, nilErr, err, tm.Result())
+	} else {
+		fmt.Println(greenColor + "TestTeamMatch : Test4 : Passed" + resetColor)
+	}
+}
@@ -1,7 +1,15 @@
 package player
 
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
 // Player represents a player in the game. It has attributes for health, strength and attack.
+// Every player carries a unique ID; the name is only a label and does not need to be unique.
 type Player struct {
+	id string
+
 	name string
 
 	health int
@@ -12,6 +20,7 @@ type Player struct {
 }
 
 // NewPlayer creates and initializes a new Player instance with the specified attributes.
+// The player is given a new unique ID.
 //
 // Parameters:
 //   - name: The name of the player.
@@ -28,7 +37,49 @@ type Player struct {
 //
 // Note: The example assumes a Player struct with exported fields (Name, Health, Strength, Attack).
 func NewPlayer(name string, health, strength, attack int) *Player {
-	return &Player{name, health, strength, attack}
+	return NewPlayerWithID(newID(), name, health, strength, attack)
+}
+
+// NewPlayerWithID creates a Player with a known ID, for example one restored from storage.
+//
+// Parameters:
+//   - id: The unique ID of the player.
+//   - name: The name of the player.
+//   - health: The health attribute of the player.
+//   - strength: The strength attribute of the player.
+//   - attack: The attack attribute of the player.
+//
+// Returns:
+//   - *Player: A pointer to the newly created Player instance.
+//
+// Example:
+//   player := NewPlayerWithID("5f2b9c0e1a7d4c36", "Name", 100, 10, 5)
+func NewPlayerWithID(id, name string, health, strength, attack int) *Player {
+	return &Player{id: id, name: name, health: health, strength: strength, attack: attack}
+}
+
+// GetPlayerID returns the unique ID of a player.
+//
+// Parameters:
+//   - p: A pointer to the Player whose ID is to be retrieved.
+//
+// Returns:
+//   - string: The unique ID of the player.
+//
+// Example:
+//   id := GetPlayerID(player)
+//   fmt.Printf("player %s\n", id)
+func GetPlayerID(p *Player) string {
+	return p.id
+}
+
+// newID returns a new random 16 character hexadecimal player ID.
+func newID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic("player: failed to generate player ID: " + err.Error())
+	}
+	return hex.EncodeToString(b)
 }
 
 // GetPlayerBaseAttributes returns the fundamental attributes of a player, including name, health, strength, and attack.
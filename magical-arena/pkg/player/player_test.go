@@ -83,6 +83,26 @@ func TestNewPlayer(t *testing.T) {
 	}
 }
 
+// TestGetPlayerID tests that every player gets a unique ID, independent of its name.
+func TestGetPlayerID(t *testing.T) {
+	//TEST 1: two players with the same name get different IDs
+	playerA := NewPlayer("shaleen", 100, 10, 5)
+	playerB := NewPlayer("shaleen", 100, 10, 5)
+	if GetPlayerID(playerA) == "" || GetPlayerID(playerA) == GetPlayerID(playerB) {
+		t.Errorf(redColor+"Expected unique IDs, got %q and %q"+resetColor, GetPlayerID(playerA), GetPlayerID(playerB))
+	} else {
+		fmt.Println(greenColor + "TestGetPlayerID : Test1 : Passed" + resetColor)
+	}
+
+	//TEST 2: a player created with a known ID keeps it
+	playerA = NewPlayerWithID("abc123", "shaleen", 100, 10, 5)
+	if GetPlayerID(playerA) != "abc123" {
+		t.Errorf(redColor+"Expected ID abc123, got %q"+resetColor, GetPlayerID(playerA))
+	} else {
+		fmt.Println(greenColor + "TestGetPlayerID : Test2 : Passed" + resetColor)
+	}
+}
+
 // TestMain runs the main testing suite.
 func TestMain(m *testing.M) {
 	fmt.Println("Testing player package...")
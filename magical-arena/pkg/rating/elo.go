@@ -0,0 +1,174 @@
+package rating
+
+import (
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"math"
+)
+
+// Default Elo settings, used for the zero fields of an EloConfig.
+const (
+	DefaultEloRating        = 1500.0
+	DefaultEloKFactor       = 32.0
+	DefaultProvisionalK     = 64.0
+	DefaultProvisionalGames = 10
+)
+
+// EloConfig holds the settings of an Elo rating system. Zero fields take the Default values.
+type EloConfig struct {
+	// InitialRating is the rating of a player who has not played yet.
+	InitialRating float64
+
+	// KFactor is the maximum rating change of an established player in a single game.
+	KFactor float64
+
+	// ProvisionalK is the K-factor used while a player is provisional, so that new ratings settle quickly.
+	ProvisionalK float64
+
+	// ProvisionalGames is the number of games a player stays provisional for. A negative value disables the provisional period.
+	ProvisionalGames int
+}
+
+// eloRecord is the rating state of a single player.
+type eloRecord struct {
+	rating float64
+	games  int
+}
+
+// Elo maintains an Elo rating for every player, keyed by player ID.
+type Elo struct {
+	config  EloConfig
+	records map[string]*eloRecord
+}
+
+// NewElo creates an Elo rating system with the given settings.
+//
+// Parameters:
+//   - config: The settings of the rating system. Zero fields take the Default values.
+//
+// Returns:
+//   - *Elo: A pointer to the newly created rating system.
+//
+// Example:
+//   elo := NewElo(EloConfig{KFactor: 24})
+func NewElo(config EloConfig) *Elo {
+	if config.InitialRating == 0 {
+		config.InitialRating = DefaultEloRating
+	}
+	if config.KFactor == 0 {
+		config.KFactor = DefaultEloKFactor
+	}
+	if config.ProvisionalK == 0 {
+		config.ProvisionalK = DefaultProvisionalK
+	}
+	if config.ProvisionalGames == 0 {
+		config.ProvisionalGames = DefaultProvisionalGames
+	}
+	return &Elo{config: config, records: make(map[string]*eloRecord)}
+}
+
+// record returns the rating state of a player, creating it with the initial rating if needed.
+func (e *Elo) record(p *player.Player) *eloRecord {
+	id := player.GetPlayerID(p)
+	if e.records[id] == nil {
+		e.records[id] = &eloRecord{rating: e.config.InitialRating}
+	}
+	return e.records[id]
+}
+
+// Rating returns the current rating of a player, or the initial rating if they have not played yet.
+func (e *Elo) Rating(p *player.Player) float64 {
+	return e.record(p).rating
+}
+
+// Games returns the number of rated games a player has played.
+func (e *Elo) Games(p *player.Player) int {
+	return e.record(p).games
+}
+
+// IsProvisional reports whether a player is still in their provisional period.
+func (e *Elo) IsProvisional(p *player.Player) bool {
+	return e.record(p).games < e.config.ProvisionalGames
+}
+
+// Expected returns the expected score of playerA against playerB: the probability of a win plus half the probability of a draw.
+//
+// Parameters:
+//   - playerA: A pointer to the player whose expected score is computed.
+//   - playerB: A pointer to the opponent.
+//
+// Returns:
+//   - float64: The expected score of playerA, between 0 and 1.
+//
+// Example:
+//   fmt.Printf("%.0f%%\n", elo.Expected(player1, player2)*100)
+func (e *Elo) Expected(playerA, playerB *player.Player) float64 {
+	return expectedScore(e.Rating(playerA), e.Rating(playerB))
+}
+
+// expectedScore returns the expected score of a player rated ratingA against a player rated ratingB.
+func expectedScore(ratingA, ratingB float64) float64 {
+	return 1 / (1 + math.Pow(10, (ratingB-ratingA)/400))
+}
+
+// Update adjusts the ratings of two players from the result of a match between them. A win scores 1,
+// a draw or a stalemate scores 0.5 for both, and an aborted or undecided match leaves the ratings unchanged.
+//
+// Parameters:
+//   - playerA: A pointer to the first player of the match.
+//   - playerB: A pointer to the second player of the match.
+//   - result: The result of the match, as returned by match.ConductMatch.
+//
+// Returns:
+//   - float64: The new rating of playerA.
+//   - float64: The new rating of playerB.
+//
+// Example:
+//   _, result := match.ConductMatch(match.NewMatch(player1, player2))
+//   newRating1, newRating2 := elo.Update(player1, player2, result)
+func (e *Elo) Update(playerA, playerB *player.Player, result match.Result) (float64, float64) {
+	a, b := e.record(playerA), e.record(playerB)
+	scoreA, ok := Score(playerA, result)
+	if !ok {
+		return a.rating, b.rating
+	}
+
+	expectedA := expectedScore(a.rating, b.rating)
+	kA, kB := e.kFactor(a), e.kFactor(b)
+	a.rating += kA * (scoreA - expectedA)
+	b.rating += kB * ((1 - scoreA) - (1 - expectedA))
+	a.games++
+	b.games++
+	return a.rating, b.rating
+}
+
+// kFactor returns the K-factor that applies to a player.
+func (e *Elo) kFactor(r *eloRecord) float64 {
+	if r.games < e.config.ProvisionalGames {
+		return e.config.ProvisionalK
+	}
+	return e.config.KFactor
+}
+
+// Score returns the score a player earned from a match result: 1 for a win, 0 for a loss and 0.5 for a draw or a stalemate.
+//
+// Parameters:
+//   - p: A pointer to one of the players of the match.
+//   - result: The result of the match.
+//
+// Returns:
+//   - float64: The score of the player.
+//   - bool: false if the match was aborted or is undecided and should not be rated.
+func Score(p *player.Player, result match.Result) (float64, bool) {
+	switch result.Outcome {
+	case match.Win:
+		if result.Winner == p {
+			return 1, true
+		}
+		return 0, true
+	case match.Draw, match.Stalemate:
+		return 0.5, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,70 @@
+package rating
+
+import (
+	"fmt"
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"math"
+	"os"
+	"testing"
+)
+
+// ANSI escape codes for text color
+const (
+	redColor   = "\033[31m"
+	greenColor = "\033[32m"
+	resetColor = "\033[0m"
+)
+
+// TestElo tests rating updates, draws, provisional K-factors and expected scores.
+func TestElo(t *testing.T) {
+	playerA := player.NewPlayer("PlayerA", 100, 10, 5)
+	playerB := player.NewPlayer("PlayerB", 100, 10, 5)
+
+	// TEST 1: equal players expect 0.5, and a provisional win moves each rating by 64 * 0.5 = 32
+	elo := NewElo(EloConfig{})
+	expected := elo.Expected(playerA, playerB)
+	ratingA, ratingB := elo.Update(playerA, playerB, match.Result{Outcome: match.Win, Winner: playerA})
+	if expected != 0.5 || ratingA != 1532 || ratingB != 1468 || elo.Games(playerA) != 1 {
+		t.Errorf(redColor+"Expected 0.5 and 1532/1468, got %v and %v/%v"+resetColor, expected, ratingA, ratingB)
+	} else {
+		fmt.Println(greenColor + "TestElo : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: a draw pulls the ratings back together, and an aborted match changes nothing
+	ratingA, ratingB = elo.Update(playerA, playerB, match.Result{Outcome: match.Stalemate})
+	abortedA, abortedB := elo.Update(playerA, playerB, match.Result{Outcome: match.Aborted})
+	if ratingA >= 1532 || ratingB <= 1468 || abortedA != ratingA || abortedB != ratingB || elo.Games(playerA) != 2 {
+		t.Errorf(redColor+"Unexpected ratings after draw %v/%v and abort %v/%v"+resetColor, ratingA, ratingB, abortedA, abortedB)
+	} else {
+		fmt.Println(greenColor + "TestElo : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: after the provisional period, the established K-factor applies
+	elo = NewElo(EloConfig{KFactor: 10, ProvisionalGames: 1})
+	elo.Update(playerA, playerB, match.Result{Outcome: match.Draw})
+	provisional := elo.IsProvisional(playerA)
+	ratingA, _ = elo.Update(playerA, playerB, match.Result{Outcome: match.Win, Winner: playerA})
+	if provisional || ratingA != 1505 {
+		t.Errorf(redColor+"Expected an established rating of 1505, got %v (provisional %t)"+resetColor, ratingA, provisional)
+	} else {
+		fmt.Println(greenColor + "TestElo : Test3 : Passed" + resetColor)
+	}
+
+	// TEST 4: a 400 point gap gives the stronger player an expected score of 10/11
+	elo = NewElo(EloConfig{})
+	elo.records[player.GetPlayerID(playerA)] = &eloRecord{rating: 1900}
+	if math.Abs(elo.Expected(playerA, playerB)-10.0/11) > 1e-9 {
+		t.Errorf(redColor+"Expected 10/11, got %v"+resetColor, elo.Expected(playerA, playerB))
+	} else {
+		fmt.Println(greenColor + "TestElo : Test4 : Passed" + resetColor)
+	}
+}
+
+// TestMain runs the main testing suite.
+func TestMain(m *testing.M) {
+	fmt.Println("Testing rating package...")
+	Result := m.Run()
+	fmt.Println("Testing complete.")
+	os.Exit(Result)
+}
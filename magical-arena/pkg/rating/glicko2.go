@@ -0,0 +1,214 @@
+package rating
+
+import (
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"math"
+)
+
+// Default Glicko-2 settings, used for the zero fields of a Glicko2Config.
+const (
+	DefaultGlickoRating     = 1500.0
+	DefaultGlickoDeviation  = 350.0
+	DefaultGlickoVolatility = 0.06
+	DefaultGlickoTau        = 0.5
+)
+
+// glickoScale converts between the Glicko rating scale and the internal Glicko-2 scale.
+const glickoScale = 173.7178
+
+// glickoEpsilon is the convergence tolerance of the volatility iteration.
+const glickoEpsilon = 0.000001
+
+// Glicko2Config holds the settings of a Glicko-2 rating system. Zero fields take the Default values.
+type Glicko2Config struct {
+	// InitialRating is the rating of a player who has not played yet.
+	InitialRating float64
+
+	// InitialDeviation is the rating deviation of a player who has not played yet. A player's deviation never decays above it.
+	InitialDeviation float64
+
+	// InitialVolatility is the volatility of a player who has not played yet.
+	InitialVolatility float64
+
+	// Tau constrains how much the volatility can change between rating periods; sensible values are 0.3 to 1.2.
+	Tau float64
+}
+
+// Glicko2Rating is a player's Glicko-2 rating on the Glicko scale.
+type Glicko2Rating struct {
+	// Rating is the player's rating.
+	Rating float64
+
+	// Deviation is the rating deviation: the uncertainty of Rating. Roughly, the true rating lies within Rating ± 2*Deviation.
+	Deviation float64
+
+	// Volatility is the expected fluctuation of the player's rating.
+	Volatility float64
+}
+
+// glickoGame is a game recorded during the current rating period, seen from one player.
+type glickoGame struct {
+	opponent string
+	score    float64
+}
+
+// Glicko2 maintains a Glicko-2 rating for every player, keyed by player ID. Match results are collected into
+// rating periods with Record and applied together by EndPeriod.
+type Glicko2 struct {
+	config  Glicko2Config
+	ratings map[string]Glicko2Rating
+	games   map[string][]glickoGame
+}
+
+// NewGlicko2 creates a Glicko-2 rating system with the given settings.
+//
+// Parameters:
+//   - config: The settings of the rating system. Zero fields take the Default values.
+//
+// Returns:
+//   - *Glicko2: A pointer to the newly created rating system.
+//
+// Example:
+//   glicko := NewGlicko2(Glicko2Config{Tau: 0.3})
+func NewGlicko2(config Glicko2Config) *Glicko2 {
+	if config.InitialRating == 0 {
+		config.InitialRating = DefaultGlickoRating
+	}
+	if config.InitialDeviation == 0 {
+		config.InitialDeviation = DefaultGlickoDeviation
+	}
+	if config.InitialVolatility == 0 {
+		config.InitialVolatility = DefaultGlickoVolatility
+	}
+	if config.Tau == 0 {
+		config.Tau = DefaultGlickoTau
+	}
+	return &Glicko2{config: config, ratings: make(map[string]Glicko2Rating), games: make(map[string][]glickoGame)}
+}
+
+// register makes sure a player has a rating, returning their ID.
+func (g *Glicko2) register(p *player.Player) string {
+	id := player.GetPlayerID(p)
+	if _, ok := g.ratings[id]; !ok {
+		g.ratings[id] = Glicko2Rating{g.config.InitialRating, g.config.InitialDeviation, g.config.InitialVolatility}
+	}
+	return id
+}
+
+// Rating returns the current rating of a player, or the initial rating if they have not played yet.
+// Results recorded in the current rating period are not reflected until EndPeriod.
+func (g *Glicko2) Rating(p *player.Player) Glicko2Rating {
+	return g.ratings[g.register(p)]
+}
+
+// Record adds the result of a match to the current rating period. Aborted and undecided matches are ignored.
+//
+// Parameters:
+//   - playerA: A pointer to the first player of the match.
+//   - playerB: A pointer to the second player of the match.
+//   - result: The result of the match, as returned by match.ConductMatch.
+//
+// Example:
+//   _, result := match.ConductMatch(match.NewMatch(player1, player2))
+//   glicko.Record(player1, player2, result)
+func (g *Glicko2) Record(playerA, playerB *player.Player, result match.Result) {
+	idA, idB := g.register(playerA), g.register(playerB)
+	scoreA, ok := Score(playerA, result)
+	if !ok {
+		return
+	}
+	g.games[idA] = append(g.games[idA], glickoGame{opponent: idB, score: scoreA})
+	g.games[idB] = append(g.games[idB], glickoGame{opponent: idA, score: 1 - scoreA})
+}
+
+// EndPeriod closes the current rating period: every player's rating is updated from the games recorded in it,
+// using the ratings from the start of the period. The deviation of players who did not fight grows, up to the initial deviation.
+//
+// Example:
+//   for _, result := range weeklyResults {
+//       glicko.Record(result.PlayerA, result.PlayerB, result.Result)
+//   }
+//   glicko.EndPeriod()
+func (g *Glicko2) EndPeriod() {
+	updated := make(map[string]Glicko2Rating, len(g.ratings))
+	for id, current := range g.ratings {
+		updated[id] = g.update(current, g.games[id])
+	}
+	g.ratings = updated
+	g.games = make(map[string][]glickoGame)
+}
+
+// update computes a player's rating at the end of a rating period, following the Glicko-2 algorithm.
+//
+// Parameters:
+//   - current: The player's rating at the start of the period.
+//   - games: The games the player played during the period.
+//
+// Returns:
+//   - Glicko2Rating: The player's rating at the end of the period.
+func (g *Glicko2) update(current Glicko2Rating, games []glickoGame) Glicko2Rating {
+	mu := (current.Rating - DefaultGlickoRating) / glickoScale
+	phi := current.Deviation / glickoScale
+	sigma := current.Volatility
+
+	//a player who did not fight only becomes less certain
+	if len(games) == 0 {
+		phi = math.Min(math.Sqrt(phi*phi+sigma*sigma), g.config.InitialDeviation/glickoScale)
+		return Glicko2Rating{current.Rating, phi * glickoScale, sigma}
+	}
+
+	//estimated variance v and improvement delta from the games of the period
+	variance, improvement := 0.0, 0.0
+	for _, game := range games {
+		opponent := g.ratings[game.opponent]
+		opponentMu := (opponent.Rating - DefaultGlickoRating) / glickoScale
+		gPhi := 1 / math.Sqrt(1+3*math.Pow(opponent.Deviation/glickoScale, 2)/(math.Pi*math.Pi))
+		expected := 1 / (1 + math.Exp(-gPhi*(mu-opponentMu)))
+		variance += gPhi * gPhi * expected * (1 - expected)
+		improvement += gPhi * (game.score - expected)
+	}
+	variance = 1 / variance
+	delta := variance * improvement
+
+	sigma = g.volatility(phi, sigma, variance, delta)
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/variance)
+	mu += phi * phi * improvement
+	return Glicko2Rating{mu*glickoScale + DefaultGlickoRating, phi * glickoScale, sigma}
+}
+
+// volatility computes the new volatility of a player with the Illinois algorithm, as in step 5 of Glicko-2.
+func (g *Glicko2) volatility(phi, sigma, variance, delta float64) float64 {
+	tau := g.config.Tau
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		return ex*(delta*delta-phi*phi-variance-ex)/(2*math.Pow(phi*phi+variance+ex, 2)) - (x-a)/(tau*tau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+variance {
+		B = math.Log(delta*delta - phi*phi - variance)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		B = a - k*tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > glickoEpsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+	return math.Exp(A / 2)
+}
@@ -0,0 +1,51 @@
+package rating
+
+import (
+	"fmt"
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"math"
+	"testing"
+)
+
+// TestGlicko2 tests a rating period against the worked example of the Glicko-2 paper, and deviation decay.
+func TestGlicko2(t *testing.T) {
+	// TEST 1: a 1500/200 player beats a 1400/30 player and loses to 1550/100 and 1700/300 players,
+	// ending the period at about 1464.06/151.52 with volatility 0.05999
+	glicko := NewGlicko2(Glicko2Config{})
+	me := player.NewPlayer("Me", 100, 10, 5)
+	opponents := []*player.Player{
+		player.NewPlayer("A", 100, 10, 5),
+		player.NewPlayer("B", 100, 10, 5),
+		player.NewPlayer("C", 100, 10, 5),
+	}
+	glicko.ratings[player.GetPlayerID(me)] = Glicko2Rating{1500, 200, 0.06}
+	glicko.ratings[player.GetPlayerID(opponents[0])] = Glicko2Rating{1400, 30, 0.06}
+	glicko.ratings[player.GetPlayerID(opponents[1])] = Glicko2Rating{1550, 100, 0.06}
+	glicko.ratings[player.GetPlayerID(opponents[2])] = Glicko2Rating{1700, 300, 0.06}
+	glicko.Record(me, opponents[0], match.Result{Outcome: match.Win, Winner: me})
+	glicko.Record(me, opponents[1], match.Result{Outcome: match.Win, Winner: opponents[1]})
+	glicko.Record(opponents[2], me, match.Result{Outcome: match.Win, Winner: opponents[2]})
+	glicko.Record(me, opponents[2], match.Result{Outcome: match.Aborted})
+	glicko.EndPeriod()
+	rating := glicko.Rating(me)
+	if math.Abs(rating.Rating-1464.06) > 0.01 || math.Abs(rating.Deviation-151.52) > 0.01 || math.Abs(rating.Volatility-0.05999) > 0.00001 {
+		t.Errorf(redColor+"Expected about 1464.06/151.52/0.05999, got %+v"+resetColor, rating)
+	} else {
+		fmt.Println(greenColor + "TestGlicko2 : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: a player without games keeps their rating while their deviation grows, up to the initial deviation
+	before := glicko.Rating(me)
+	glicko.EndPeriod()
+	after := glicko.Rating(me)
+	for i := 0; i < 10000; i++ {
+		glicko.EndPeriod()
+	}
+	capped := glicko.Rating(me)
+	if after.Rating != before.Rating || after.Deviation <= before.Deviation || capped.Deviation != DefaultGlickoDeviation {
+		t.Errorf(redColor+"Unexpected decay %+v -> %+v -> %+v"+resetColor, before, after, capped)
+	} else {
+		fmt.Println(greenColor + "TestGlicko2 : Test2 : Passed" + resetColor)
+	}
+}
@@ -0,0 +1,352 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"os"
+	"strings"
+)
+
+// Kind is the role of a message, which renderers may style differently.
+type Kind int
+
+const (
+	// Text is an unstyled message.
+	Text Kind = iota
+
+	// Title is a heading, such as a welcome banner.
+	Title
+
+	// Notice is a status message, such as "Entering the arena...".
+	Notice
+
+	// Menu lists the choices the user can make.
+	Menu
+
+	// Success reports something that went well, such as a match result.
+	Success
+
+	// Error reports something that went wrong.
+	Error
+)
+
+// String returns the lower-case name of the kind, e.g. "title" or "error".
+func (k Kind) String() string {
+	switch k {
+	case Text:
+		return "text"
+	case Title:
+		return "title"
+	case Notice:
+		return "notice"
+	case Menu:
+		return "menu"
+	case Success:
+		return "success"
+	case Error:
+		return "error"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// Renderer formats the output of the arena for a particular kind of destination.
+type Renderer interface {
+	// Message writes a line of text of the given kind.
+	Message(kind Kind, text string)
+
+	// Prompt writes a prompt for user input, without ending the line.
+	Prompt(text string)
+
+	// Round writes the event of a round.
+	Round(event match.RoundEvent)
+
+	// Result writes the result of a match.
+	Result(result match.Result)
+}
+
+// Output formats accepted by New.
+const (
+	FormatAuto     = "auto"
+	FormatANSI     = "ansi"
+	FormatPlain    = "plain"
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
+// New creates a renderer of the given format writing to w.
+//
+// Parameters:
+//   - format: One of FormatAuto (or empty), FormatANSI, FormatPlain, FormatJSON or FormatMarkdown.
+//   - w: The writer to render to.
+//
+// Returns:
+//   - Renderer: The renderer.
+//   - error: An error if the format is unknown.
+//
+// Example:
+//   out, err := New(FormatMarkdown, os.Stdout)
+func New(format string, w io.Writer) (Renderer, error) {
+	switch format {
+	case "", FormatAuto:
+		return Auto(w), nil
+	case FormatANSI:
+		return NewANSI(w), nil
+	case FormatPlain:
+		return NewPlain(w), nil
+	case FormatJSON:
+		return NewJSON(w), nil
+	case FormatMarkdown:
+		return NewMarkdown(w), nil
+	default:
+		return nil, fmt.Errorf("render: unknown format %q", format)
+	}
+}
+
+// Auto returns an ANSI renderer when w is a terminal and the NO_COLOR environment variable is not set to
+// a non-empty value, and a plain text renderer otherwise.
+//
+// Parameters:
+//   - w: The writer to render to.
+//
+// Returns:
+//   - Renderer: The renderer.
+//
+// Example:
+//   out := Auto(os.Stdout)
+func Auto(w io.Writer) Renderer {
+	if !noColor() && IsTerminal(w) {
+		return NewANSI(w)
+	}
+	return NewPlain(w)
+}
+
+// noColor reports whether colors are turned off by the NO_COLOR environment variable,
+// which only applies when it is set to a non-empty value.
+func noColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// IsTerminal reports whether w is a terminal.
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// resultLine returns the text line of a match result.
+func resultLine(result match.Result) string {
+	return "Match result: " + result.String()
+}
+
+// plain renders unstyled text.
+type plain struct {
+	w io.Writer
+}
+
+// NewPlain creates a renderer writing unstyled text, suitable for files and pipes.
+func NewPlain(w io.Writer) Renderer {
+	return &plain{w: w}
+}
+
+// Message writes the text as is.
+func (r *plain) Message(kind Kind, text string) {
+	fmt.Fprintln(r.w, text)
+}
+
+// Prompt writes the prompt as is.
+func (r *plain) Prompt(text string) {
+	fmt.Fprint(r.w, text)
+}
+
+// Round writes the text form of the round.
+func (r *plain) Round(event match.RoundEvent) {
+	fmt.Fprintln(r.w, event.String())
+}
+
+// Result writes "Match result: " and the text form of the result.
+func (r *plain) Result(result match.Result) {
+	fmt.Fprintln(r.w, resultLine(result))
+}
+
+// ANSI escape codes for text color
+const (
+	redColor     = "\033[31m"
+	greenColor   = "\033[32m"
+	yellowColor  = "\033[33m"
+	magentaColor = "\033[35m"
+	cyanColor    = "\033[36m"
+	resetColor   = "\033[0m"
+)
+
+// ansi renders text colored with ANSI escape codes.
+type ansi struct {
+	w io.Writer
+}
+
+// NewANSI creates a renderer writing text colored with ANSI escape codes, for terminals.
+func NewANSI(w io.Writer) Renderer {
+	return &ansi{w: w}
+}
+
+// colors maps the kinds of messages to their color.
+var colors = map[Kind]string{
+	Title:   cyanColor,
+	Notice:  magentaColor,
+	Menu:    yellowColor,
+	Success: greenColor,
+	Error:   redColor,
+}
+
+// Message writes the text in the color of its kind.
+func (r *ansi) Message(kind Kind, text string) {
+	if color, ok := colors[kind]; ok {
+		text = color + text + resetColor
+	}
+	fmt.Fprintln(r.w, text)
+}
+
+// Prompt writes the prompt uncolored.
+func (r *ansi) Prompt(text string) {
+	fmt.Fprint(r.w, text)
+}
+
+// Round writes the text form of the round.
+func (r *ansi) Round(event match.RoundEvent) {
+	fmt.Fprintln(r.w, event.String())
+}
+
+// Result writes the text form of the result as a Success message.
+func (r *ansi) Result(result match.Result) {
+	r.Message(Success, resultLine(result))
+}
+
+// jsonLines renders one JSON object per line.
+type jsonLines struct {
+	encoder *json.Encoder
+}
+
+// NewJSON creates a renderer writing one JSON object per line, for other programs to consume.
+// Every object has a "type" field: "message", "prompt", "round" or "result".
+func NewJSON(w io.Writer) Renderer {
+	return &jsonLines{encoder: json.NewEncoder(w)}
+}
+
+// jsonMessage is the JSON form of messages and prompts.
+type jsonMessage struct {
+	Type string `json:"type"`
+	Kind string `json:"kind,omitempty"`
+	Text string `json:"text"`
+}
+
+// jsonRound is the JSON form of a round event.
+type jsonRound struct {
+	Type string `json:"type"`
+	match.RoundEvent
+}
+
+// jsonResult is the JSON form of a match result.
+type jsonResult struct {
+	Type     string        `json:"type"`
+	Outcome  match.Outcome `json:"outcome"`
+	Winner   string        `json:"winner,omitempty"`
+	WinnerID string        `json:"winnerId,omitempty"`
+	Rounds   int           `json:"rounds"`
+	Text     string        `json:"text"`
+}
+
+// Message writes a "message" object with the kind and text.
+func (r *jsonLines) Message(kind Kind, text string) {
+	r.encoder.Encode(jsonMessage{Type: "message", Kind: kind.String(), Text: text})
+}
+
+// Prompt writes a "prompt" object with the text.
+func (r *jsonLines) Prompt(text string) {
+	r.encoder.Encode(jsonMessage{Type: "prompt", Text: text})
+}
+
+// Round writes a "round" object with every field of the event.
+func (r *jsonLines) Round(event match.RoundEvent) {
+	r.encoder.Encode(jsonRound{Type: "round", RoundEvent: event})
+}
+
+// Result writes a "result" object with the outcome, winner, rounds and text form of the result.
+func (r *jsonLines) Result(result match.Result) {
+	line := jsonResult{Type: "result", Outcome: result.Outcome, Rounds: result.Rounds, Text: result.String()}
+	if result.Winner != nil {
+		line.Winner, _, _, _ = player.GetPlayerBaseAttributes(result.Winner)
+		line.WinnerID = player.GetPlayerID(result.Winner)
+	}
+	r.encoder.Encode(line)
+}
+
+// markdown renders Markdown, with the rounds of a match as a table.
+type markdown struct {
+	w       io.Writer
+	inTable bool
+}
+
+// NewMarkdown creates a renderer writing Markdown, for reports and documentation.
+func NewMarkdown(w io.Writer) Renderer {
+	return &markdown{w: w}
+}
+
+// endTable closes the table of rounds, if one is open.
+func (r *markdown) endTable() {
+	if r.inTable {
+		fmt.Fprintln(r.w)
+		r.inTable = false
+	}
+}
+
+// escape keeps text from being read as Markdown or breaking a table.
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "|", `\|`, "#", `\#`, "`", "\\`").Replace(text)
+}
+
+// Message writes the text as a paragraph: a heading for Title, emphasis for Notice and Success, a quote for Error.
+func (r *markdown) Message(kind Kind, text string) {
+	r.endTable()
+	text = escape(text)
+	switch kind {
+	case Title:
+		text = "## " + text
+	case Notice:
+		text = "_" + text + "_"
+	case Success:
+		text = "**" + text + "**"
+	case Error:
+		text = "> " + text
+	}
+	fmt.Fprintln(r.w, text)
+	fmt.Fprintln(r.w)
+}
+
+// Prompt writes the prompt as is.
+func (r *markdown) Prompt(text string) {
+	r.endTable()
+	fmt.Fprint(r.w, escape(text))
+}
+
+// Round writes the round as a row of the rounds table, starting the table if needed.
+func (r *markdown) Round(event match.RoundEvent) {
+	if !r.inTable {
+		fmt.Fprintln(r.w, "| Round | Attacker | Defender | Attack roll | Defence roll | Damage | Defender health |")
+		fmt.Fprintln(r.w, "|------:|----------|----------|------------:|-------------:|-------:|----------------:|")
+		r.inTable = true
+	}
+	fmt.Fprintf(r.w, "| %d | %s | %s | %d | %d | %d | %d |\n", event.Round, escape(event.Attacker), escape(event.Defender),
+		event.AttackRoll, event.DefenceRoll, event.Damage, event.DefenderHealth)
+}
+
+// Result writes the result as a bold paragraph.
+func (r *markdown) Result(result match.Result) {
+	r.endTable()
+	fmt.Fprintf(r.w, "**Match result:** %s\n\n", escape(result.String()))
+}
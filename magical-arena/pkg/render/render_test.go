@@ -0,0 +1,105 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"os"
+	"strings"
+	"testing"
+)
+
+// event is a round event shared by the tests.
+var event = match.RoundEvent{Round: 1, Attacker: "Hero", Defender: "Brute", AttackRoll: 5, DefenceRoll: 2, Attack: 25, Defence: 16, Damage: 9, DefenderHealth: 71}
+
+// TestRenderers tests the output of every renderer.
+func TestRenderers(t *testing.T) {
+	hero := player.NewPlayer("Hero", 100, 10, 5)
+	result := match.Result{Outcome: match.Win, Winner: hero, Rounds: 1}
+
+	// TEST 1: plain output has no escape codes, and ANSI output colors messages by kind
+	var plainOut, ansiOut bytes.Buffer
+	for _, r := range []Renderer{NewPlain(&plainOut), NewANSI(&ansiOut)} {
+		r.Message(Error, "Oops")
+		r.Round(event)
+		r.Result(result)
+	}
+	if plainOut.String() != "Oops\nHero attacked Brute for 9 damage\nMatch result: Hero wins\n" ||
+		!strings.HasPrefix(ansiOut.String(), redColor+"Oops"+resetColor+"\n") || !strings.Contains(ansiOut.String(), greenColor+"Match result: Hero wins") {
+		t.Errorf(redColor+"Unexpected output %q / %q"+resetColor, plainOut.String(), ansiOut.String())
+	} else {
+		fmt.Println(greenColor + "TestRenderers : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: JSON output is one object per line, typed, with the round fields and the result
+	var jsonOut bytes.Buffer
+	r := NewJSON(&jsonOut)
+	r.Message(Notice, "Hello")
+	r.Round(event)
+	r.Result(result)
+	lines := strings.Split(strings.TrimSpace(jsonOut.String()), "\n")
+	var message, round, final map[string]interface{}
+	json.Unmarshal([]byte(lines[0]), &message)
+	json.Unmarshal([]byte(lines[1]), &round)
+	json.Unmarshal([]byte(lines[2]), &final)
+	if len(lines) != 3 || message["type"] != "message" || message["kind"] != "notice" || round["type"] != "round" ||
+		round["damage"] != 9.0 || final["outcome"] != "win" || final["winnerId"] != player.GetPlayerID(hero) {
+		t.Errorf(redColor+"Unexpected JSON output %q"+resetColor, jsonOut.String())
+	} else {
+		fmt.Println(greenColor + "TestRenderers : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: Markdown output uses headings and a table of rounds, escaping special characters
+	var markdownOut bytes.Buffer
+	r = NewMarkdown(&markdownOut)
+	r.Message(Title, "Round *one*")
+	r.Round(event)
+	r.Round(event)
+	r.Result(result)
+	text := markdownOut.String()
+	if !strings.HasPrefix(text, "## Round \\*one\\*\n\n| Round |") || strings.Count(text, "| Round |") != 1 ||
+		strings.Count(text, "| 1 | Hero | Brute | 5 | 2 | 9 | 71 |") != 2 || !strings.HasSuffix(text, "|\n\n**Match result:** Hero wins\n\n") {
+		t.Errorf(redColor+"Unexpected Markdown output %q"+resetColor, text)
+	} else {
+		fmt.Println(greenColor + "TestRenderers : Test3 : Passed" + resetColor)
+	}
+}
+
+// TestNew tests choosing a renderer by format.
+func TestNew(t *testing.T) {
+	// TEST 1: a writer that is not a terminal gets plain output, and unknown formats are rejected
+	var out bytes.Buffer
+	auto, err := New(FormatAuto, &out)
+	_, bad := New("html", &out)
+	if _, ok := auto.(*plain); !ok || err != nil || bad == nil || IsTerminal(&out) {
+		t.Errorf(redColor+"Unexpected renderer %T, %v, %v"+resetColor, auto, err, bad)
+	} else {
+		fmt.Println(greenColor + "TestNew : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: NO_COLOR forces plain output
+	t.Setenv("NO_COLOR", "1")
+	if _, ok := Auto(os.Stdout).(*plain); !ok {
+		t.Errorf(redColor + "Expected plain output with NO_COLOR set" + resetColor)
+	} else {
+		fmt.Println(greenColor + "TestNew : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: NO_COLOR set to an empty value does not turn colors off
+	t.Setenv("NO_COLOR", "")
+	if noColor() {
+		t.Errorf(redColor + "Expected colors to stay on with an empty NO_COLOR" + resetColor)
+	} else {
+		fmt.Println(greenColor + "TestNew : Test3 : Passed" + resetColor)
+	}
+}
+
+// TestMain runs the main testing suite.
+func TestMain(m *testing.M) {
+	fmt.Println("Testing render package...")
+	Result := m.Run()
+	fmt.Println("Testing complete.")
+	os.Exit(Result)
+}
@@ -0,0 +1,231 @@
+package roster
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"magical-arena/pkg/player"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ErrNotFound is returned when no player in the roster has the requested name.
+var ErrNotFound = errors.New("roster: player not found")
+
+// ErrDuplicateName is returned when a player with the same name is already in the roster.
+var ErrDuplicateName = errors.New("roster: a player with this name already exists")
+
+// record is the JSON form of a player in the roster file.
+type record struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Health   int    `json:"health"`
+	Strength int    `json:"strength"`
+	Attack   int    `json:"attack"`
+}
+
+// Store is a roster of player definitions kept in a JSON file. Players are looked up by name, which is unique
+// within a roster, and keep their ID across sessions. Every change is written to the file immediately.
+type Store struct {
+	path    string
+	players map[string]*player.Player
+}
+
+// Open loads the roster stored at the given path. A missing file is treated as an empty roster,
+// which is created on the first change.
+//
+// Parameters:
+//   - path: The path of the roster file.
+//
+// Returns:
+//   - *Store: A pointer to the loaded roster.
+//   - error: An error if the file exists but cannot be read or parsed.
+//
+// Example:
+//   store, err := Open("roster.json")
+//   if err != nil {
+//       log.Fatal(err)
+//   }
+func Open(path string) (*Store, error) {
+	store := &Store{path: path, players: make(map[string]*player.Player)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("roster: failed to read %s: %w", path, err)
+	}
+
+	var records []record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("roster: failed to parse %s: %w", path, err)
+	}
+	for _, r := range records {
+		if _, ok := store.players[r.Name]; ok {
+			return nil, fmt.Errorf("roster: %s lists %q more than once", path, r.Name)
+		}
+		store.players[r.Name] = player.NewPlayerWithID(r.ID, r.Name, r.Health, r.Strength, r.Attack)
+	}
+	return store, nil
+}
+
+// Path returns the path of the roster file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Create adds a new player to the roster and saves it.
+//
+// Parameters:
+//   - name: The name of the player, unique within the roster.
+//   - health: The health attribute of the player.
+//   - strength: The strength attribute of the player.
+//   - attack: The attack attribute of the player.
+//
+// Returns:
+//   - *player.Player: A pointer to the new player, with a new unique ID.
+//   - error: ErrDuplicateName if the name is taken, or an error if the roster cannot be saved.
+//
+// Example:
+//   hero, err := store.Create("Hero", 100, 10, 5)
+func (s *Store) Create(name string, health, strength, attack int) (*player.Player, error) {
+	if _, ok := s.players[name]; ok {
+		return nil, ErrDuplicateName
+	}
+	p := player.NewPlayer(name, health, strength, attack)
+	s.players[name] = p
+	if err := s.save(); err != nil {
+		delete(s.players, name)
+		return nil, err
+	}
+	return p, nil
+}
+
+// Get returns the player with the given name.
+//
+// Parameters:
+//   - name: The name of the player.
+//
+// Returns:
+//   - *player.Player: A pointer to the player.
+//   - error: ErrNotFound if no player has the name.
+//
+// Example:
+//   hero, err := store.Get("Hero")
+func (s *Store) Get(name string) (*player.Player, error) {
+	p, ok := s.players[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return p, nil
+}
+
+// Update changes the attributes of a player in the roster and saves it. The player keeps their ID.
+//
+// Parameters:
+//   - name: The name of the player.
+//   - health: The new health attribute of the player.
+//   - strength: The new strength attribute of the player.
+//   - attack: The new attack attribute of the player.
+//
+// Returns:
+//   - *player.Player: A pointer to the updated player.
+//   - error: ErrNotFound if no player has the name, or an error if the roster cannot be saved.
+//
+// Example:
+//   hero, err := store.Update("Hero", 120, 10, 6)
+func (s *Store) Update(name string, health, strength, attack int) (*player.Player, error) {
+	old, ok := s.players[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	p := player.NewPlayerWithID(player.GetPlayerID(old), name, health, strength, attack)
+	s.players[name] = p
+	if err := s.save(); err != nil {
+		s.players[name] = old
+		return nil, err
+	}
+	return p, nil
+}
+
+// Delete removes a player from the roster and saves it.
+//
+// Parameters:
+//   - name: The name of the player.
+//
+// Returns:
+//   - error: ErrNotFound if no player has the name, or an error if the roster cannot be saved.
+//
+// Example:
+//   err := store.Delete("Hero")
+func (s *Store) Delete(name string) error {
+	old, ok := s.players[name]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(s.players, name)
+	if err := s.save(); err != nil {
+		s.players[name] = old
+		return err
+	}
+	return nil
+}
+
+// List returns every player in the roster, sorted by name.
+//
+// Returns:
+//   - []*player.Player: The players of the roster.
+//
+// Example:
+//   for _, p := range store.List() {
+//       name, health, strength, attack := player.GetPlayerBaseAttributes(p)
+//       fmt.Printf("%s: %d/%d/%d\n", name, health, strength, attack)
+//   }
+func (s *Store) List() []*player.Player {
+	players := make([]*player.Player, 0, len(s.players))
+	for _, p := range s.players {
+		players = append(players, p)
+	}
+	sort.Slice(players, func(i, j int) bool {
+		nameI, _, _, _ := player.GetPlayerBaseAttributes(players[i])
+		nameJ, _, _, _ := player.GetPlayerBaseAttributes(players[j])
+		return nameI < nameJ
+	})
+	return players
+}
+
+// save writes the roster to its file. The file is replaced atomically, so an interrupted save never leaves it half written.
+func (s *Store) save() error {
+	records := make([]record, 0, len(s.players))
+	for _, p := range s.List() {
+		name, health, strength, attack := player.GetPlayerBaseAttributes(p)
+		records = append(records, record{player.GetPlayerID(p), name, health, strength, attack})
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("roster: failed to encode roster: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("roster: failed to create %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("roster: failed to save %s: %w", s.path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("roster: failed to save %s: %w", s.path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("roster: failed to save %s: %w", s.path, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("roster: failed to save %s: %w", s.path, err)
+	}
+	return nil
+}
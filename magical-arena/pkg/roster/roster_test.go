@@ -0,0 +1,88 @@
+package roster
+
+import (
+	"errors"
+	"fmt"
+	"magical-arena/pkg/player"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ANSI escape codes for text color
+const (
+	redColor   = "\033[31m"
+	greenColor = "\033[32m"
+	resetColor = "\033[0m"
+)
+
+// TestStore tests creating, updating, deleting and listing players, and reloading the roster from disk.
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "arena", "roster.json")
+
+	// TEST 1: a missing file opens as an empty roster
+	store, err := Open(path)
+	if err != nil || len(store.List()) != 0 {
+		t.Fatalf(redColor+"Expected an empty roster, got %v, %v"+resetColor, store, err)
+	}
+	fmt.Println(greenColor + "TestStore : Test1 : Passed" + resetColor)
+
+	// TEST 2: created players are saved, and names are unique
+	hero, err := store.Create("Hero", 100, 10, 5)
+	if err != nil {
+		t.Fatalf(redColor+"Unexpected error %v"+resetColor, err)
+	}
+	_, _ = store.Create("Brute", 200, 5, 10)
+	if _, err := store.Create("Hero", 1, 1, 1); !errors.Is(err, ErrDuplicateName) {
+		t.Errorf(redColor+"Expected ErrDuplicateName, got %v"+resetColor, err)
+	} else {
+		fmt.Println(greenColor + "TestStore : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: an update keeps the player's ID, and the roster reloads from disk sorted by name
+	if _, err := store.Update("Hero", 120, 12, 6); err != nil {
+		t.Fatalf(redColor+"Unexpected error %v"+resetColor, err)
+	}
+	reloaded, err := Open(path)
+	if err != nil {
+		t.Fatalf(redColor+"Unexpected error %v"+resetColor, err)
+	}
+	list := reloaded.List()
+	loaded, _ := reloaded.Get("Hero")
+	name, health, strength, attack := player.GetPlayerBaseAttributes(loaded)
+	firstName, _, _, _ := player.GetPlayerBaseAttributes(list[0])
+	if len(list) != 2 || firstName != "Brute" || player.GetPlayerID(loaded) != player.GetPlayerID(hero) ||
+		name != "Hero" || health != 120 || strength != 12 || attack != 6 {
+		t.Errorf(redColor+"Unexpected reloaded roster %v"+resetColor, list)
+	} else {
+		fmt.Println(greenColor + "TestStore : Test3 : Passed" + resetColor)
+	}
+
+	// TEST 4: deleted players are gone after a reload, and missing players report ErrNotFound
+	err = reloaded.Delete("Brute")
+	again, _ := Open(path)
+	_, getErr := again.Get("Brute")
+	if err != nil || !errors.Is(getErr, ErrNotFound) || !errors.Is(again.Delete("Brute"), ErrNotFound) || len(again.List()) != 1 {
+		t.Errorf(redColor+"Unexpected delete result %v, %v"+resetColor, err, getErr)
+	} else {
+		fmt.Println(greenColor + "TestStore : Test4 : Passed" + resetColor)
+	}
+
+	// TEST 5: a corrupt file is reported instead of being overwritten
+	if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Errorf(redColor + "Expected an error for a corrupt roster" + resetColor)
+	} else {
+		fmt.Println(greenColor + "TestStore : Test5 : Passed" + resetColor)
+	}
+}
+
+// TestMain runs the main testing suite.
+func TestMain(m *testing.M) {
+	fmt.Println("Testing roster package...")
+	Result := m.Run()
+	fmt.Println("Testing complete.")
+	os.Exit(Result)
+}
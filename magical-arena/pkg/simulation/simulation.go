@@ -0,0 +1,228 @@
+package simulation
+
+import (
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// z95 is the z-score of a two-sided 95% confidence interval.
+const z95 = 1.959964
+
+// Config describes a batch of simulated matches between the same two players.
+type Config struct {
+	// Runs is the number of matches to play.
+	Runs int
+
+	// Workers is the number of matches played concurrently. 0 means runtime.NumCPU().
+	Workers int
+
+	// Seed is the base seed of the batch. Match i is played with seed Seed+i, so a batch is
+	// fully reproducible regardless of the number of workers.
+	Seed int64
+
+	// NewDice creates the dice of a match from its seed. It is called once for every match, by the worker
+	// playing it, so that no dice are shared between workers and the batch stays reproducible from Seed.
+	// nil means match.NewSeededDice.
+	NewDice func(seed int64) match.Dice
+
+	// Options are extra match options applied to every match, such as match.WithMaxRounds.
+	// The dice of every match are always created by Run from NewDice, so match.WithDice has no effect here:
+	// a single Dice would otherwise be shared by all workers.
+	Options []match.Option
+}
+
+// Interval is a confidence interval around a rate.
+type Interval struct {
+	// Low is the lower bound of the interval.
+	Low float64
+
+	// High is the upper bound of the interval.
+	High float64
+}
+
+// Report summarises the results of a batch of simulated matches.
+type Report struct {
+	// Runs is the number of matches played.
+	Runs int
+
+	// WinsA is the number of matches won by Player A.
+	WinsA int
+
+	// WinsB is the number of matches won by Player B.
+	WinsB int
+
+	// Draws is the number of matches that ended in a draw.
+	Draws int
+
+	// Stalemates is the number of matches that ended in a stalemate.
+	Stalemates int
+
+	// WinRateA is the fraction of matches won by Player A.
+	WinRateA float64
+
+	// WinRateB is the fraction of matches won by Player B.
+	WinRateB float64
+
+	// WinRateAInterval is the 95% confidence interval of WinRateA.
+	WinRateAInterval Interval
+
+	// WinRateBInterval is the 95% confidence interval of WinRateB.
+	WinRateBInterval Interval
+
+	// RoundCounts maps a number of rounds to the number of matches that lasted that long.
+	RoundCounts map[int]int
+
+	// MinRounds is the number of rounds of the shortest match.
+	MinRounds int
+
+	// MaxRounds is the number of rounds of the longest match.
+	MaxRounds int
+
+	// MeanRounds is the average number of rounds of a match.
+	MeanRounds float64
+
+	// AverageHealthA is Player A's average remaining health at the end of a match.
+	AverageHealthA float64
+
+	// AverageHealthB is Player B's average remaining health at the end of a match.
+	AverageHealthB float64
+
+	// AverageWinnerHealth is the winner's average remaining health, over the matches that had a winner.
+	AverageWinnerHealth float64
+}
+
+// outcome is the summary of a single simulated match.
+type outcome struct {
+	result  match.Result
+	rounds  int
+	healthA int
+	healthB int
+}
+
+// Run plays config.Runs matches between playerA and playerB across a pool of workers and summarises the results.
+//
+// Parameters:
+//   - playerA: A pointer to Player A.
+//   - playerB: A pointer to Player B.
+//   - config: The number of runs, workers, base seed and match options of the batch.
+//
+// Returns:
+//   - Report: The win rates, confidence intervals, round-count distribution and average remaining health of the batch.
+//
+// Example:
+//   report := simulation.Run(player1, player2, simulation.Config{Runs: 10000, Seed: 1})
+//   fmt.Printf("A wins %.1f%% (%.1f%% - %.1f%%)\n", report.WinRateA*100, report.WinRateAInterval.Low*100, report.WinRateAInterval.High*100)
+func Run(playerA, playerB *player.Player, config Config) Report {
+	workers := config.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	newDice := config.NewDice
+	if newDice == nil {
+		newDice = match.NewSeededDice
+	}
+
+	//feeding match numbers to the workers
+	jobs := make(chan int)
+	go func() {
+		for i := 0; i < config.Runs; i++ {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	//each worker plays matches with their own deterministic seed and their own dice, applied after
+	//the options so that dice given with match.WithDice are never shared between workers
+	results := make(chan outcome)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				seed := config.Seed + int64(i)
+				options := append(append([]match.Option{}, config.Options...), match.WithSeed(seed), match.WithDice(newDice(seed)))
+				currentMatch := match.NewMatch(playerA, playerB, options...)
+				events, result := match.ConductMatch(currentMatch)
+				state := currentMatch.State()
+				results <- outcome{result, len(events), state.HealthA, state.HealthB}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	report := Report{RoundCounts: make(map[int]int)}
+	totalRounds, totalHealthA, totalHealthB, totalWinnerHealth := 0, 0, 0, 0
+	for o := range results {
+		report.Runs++
+		report.RoundCounts[o.rounds]++
+		if report.Runs == 1 || o.rounds < report.MinRounds {
+			report.MinRounds = o.rounds
+		}
+		if o.rounds > report.MaxRounds {
+			report.MaxRounds = o.rounds
+		}
+		totalRounds += o.rounds
+		totalHealthA += o.healthA
+		totalHealthB += o.healthB
+
+		switch {
+		case o.result.Outcome == match.Win && o.result.Winner == playerA:
+			report.WinsA++
+			totalWinnerHealth += o.healthA
+		case o.result.Outcome == match.Win:
+			report.WinsB++
+			totalWinnerHealth += o.healthB
+		case o.result.Outcome == match.Draw:
+			report.Draws++
+		case o.result.Outcome == match.Stalemate:
+			report.Stalemates++
+		}
+	}
+
+	if report.Runs == 0 {
+		return report
+	}
+	runs := float64(report.Runs)
+	report.WinRateA = float64(report.WinsA) / runs
+	report.WinRateB = float64(report.WinsB) / runs
+	report.WinRateAInterval = WilsonInterval(report.WinsA, report.Runs)
+	report.WinRateBInterval = WilsonInterval(report.WinsB, report.Runs)
+	report.MeanRounds = float64(totalRounds) / runs
+	report.AverageHealthA = float64(totalHealthA) / runs
+	report.AverageHealthB = float64(totalHealthB) / runs
+	if wins := report.WinsA + report.WinsB; wins > 0 {
+		report.AverageWinnerHealth = float64(totalWinnerHealth) / float64(wins)
+	}
+	return report
+}
+
+// WilsonInterval returns the 95% Wilson score confidence interval of a success rate.
+//
+// Parameters:
+//   - successes: The number of successful trials.
+//   - trials: The total number of trials.
+//
+// Returns:
+//   - Interval: The confidence interval, or an empty interval when there were no trials.
+//
+// Example:
+//   interval := WilsonInterval(520, 1000)
+//   fmt.Printf("%.3f - %.3f\n", interval.Low, interval.High)
+func WilsonInterval(successes, trials int) Interval {
+	if trials <= 0 {
+		return Interval{}
+	}
+	n := float64(trials)
+	p := float64(successes) / n
+	denominator := 1 + z95*z95/n
+	centre := (p + z95*z95/(2*n)) / denominator
+	margin := z95 * math.Sqrt(p*(1-p)/n+z95*z95/(4*n*n)) / denominator
+	return Interval{Low: math.Max(0, centre-margin), High: math.Min(1, centre+margin)}
+}
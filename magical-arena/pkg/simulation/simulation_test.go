@@ -0,0 +1,83 @@
+package simulation
+
+import (
+	"fmt"
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// ANSI escape codes for text color
+const (
+	redColor   = "\033[31m"
+	greenColor = "\033[32m"
+	resetColor = "\033[0m"
+)
+
+// TestRun tests that a batch of simulated matches is reproducible and consistent with the exact odds.
+func TestRun(t *testing.T) {
+	playerA := player.NewPlayer("PlayerA", 50, 5, 10)
+	playerB := player.NewPlayer("PlayerB", 100, 10, 5)
+
+	// TEST 1: the same seed gives the same report regardless of the number of workers
+	first := Run(playerA, playerB, Config{Runs: 2000, Workers: 1, Seed: 7})
+	second := Run(playerA, playerB, Config{Runs: 2000, Workers: 8, Seed: 7})
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf(redColor+"Expected identical reports, got %+v and %+v"+resetColor, first, second)
+	} else {
+		fmt.Println(greenColor + "TestRun : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: the counts add up and the exact win probability lies inside the confidence interval
+	odds := match.WinProbability(playerA, playerB, match.DefaultRules())
+	rounds := 0
+	for _, count := range first.RoundCounts {
+		rounds += count
+	}
+	if first.Runs != 2000 || first.WinsA+first.WinsB+first.Draws+first.Stalemates != 2000 || rounds != 2000 ||
+		odds.WinA < first.WinRateAInterval.Low || odds.WinA > first.WinRateAInterval.High {
+		t.Errorf(redColor+"Unexpected report %+v for exact odds %+v"+resetColor, first, odds)
+	} else {
+		fmt.Println(greenColor + "TestRun : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: match options are applied to every match
+	report := Run(playerA, playerB, Config{Runs: 100, Seed: 1, Options: []match.Option{match.WithMaxRounds(1)}})
+	if report.MaxRounds != 1 || report.Draws != 100 {
+		t.Errorf(redColor+"Expected 100 one-round draws, got %+v"+resetColor, report)
+	} else {
+		fmt.Println(greenColor + "TestRun : Test3 : Passed" + resetColor)
+	}
+
+	// TEST 4: every match gets its own dice from NewDice, and dice given as an option are not shared between workers
+	shared := match.NewScriptedDice(1)
+	report = Run(playerA, playerB, Config{Runs: 200, Workers: 8, Seed: 1,
+		NewDice: func(seed int64) match.Dice { return match.NewScriptedDice(6, 1) },
+		Options: []match.Option{match.WithDice(shared)}})
+	if report.WinsA != 200 || report.MinRounds != report.MaxRounds {
+		t.Errorf(redColor+"Expected 200 identical wins for PlayerA, got %+v"+resetColor, report)
+	} else {
+		fmt.Println(greenColor + "TestRun : Test4 : Passed" + resetColor)
+	}
+}
+
+// TestWilsonInterval tests the 95% Wilson score interval.
+func TestWilsonInterval(t *testing.T) {
+	// TEST 1: 50 successes out of 100 gives roughly 0.404 - 0.596
+	interval := WilsonInterval(50, 100)
+	if interval.Low < 0.403 || interval.Low > 0.405 || interval.High < 0.595 || interval.High > 0.597 {
+		t.Errorf(redColor+"Expected about 0.404 - 0.596, got %+v"+resetColor, interval)
+	} else {
+		fmt.Println(greenColor + "TestWilsonInterval : Test1 : Passed" + resetColor)
+	}
+}
+
+// TestMain runs the main testing suite.
+func TestMain(m *testing.M) {
+	fmt.Println("Testing simulation package...")
+	Result := m.Run()
+	fmt.Println("Testing complete.")
+	os.Exit(Result)
+}
@@ -0,0 +1,289 @@
+package tournament
+
+import "magical-arena/pkg/player"
+
+// WithoutGrandFinalReset makes the grand final of a double-elimination bracket a single game. By default, when the
+// losers bracket champion wins the grand final, both players have one loss and a deciding reset game is played.
+//
+// Returns:
+//   - Option: An option to pass to NewDoubleElimination.
+//
+// Example:
+//   bracket, err := NewDoubleElimination(roster, WithoutGrandFinalReset())
+func WithoutGrandFinalReset() Option {
+	return func(c *config) {
+		c.noGrandFinalReset = true
+	}
+}
+
+// bracketNode is a pairing of a double-elimination bracket together with where its winner and loser go next.
+type bracketNode struct {
+	Pairing
+
+	// resolvedA and resolvedB report whether the slots are final. A resolved slot without a player is empty,
+	// for example when it would have been filled by the loser of a bye.
+	resolvedA, resolvedB bool
+
+	// done reports whether the pairing has been decided.
+	done bool
+
+	// winnerTo and loserTo are the pairings the winner and loser move to, nil if they leave the bracket.
+	winnerTo, loserTo *bracketNode
+
+	// winnerSlot and loserSlot are the slots (0 for PlayerA, 1 for PlayerB) they take there.
+	winnerSlot, loserSlot int
+}
+
+// DoubleElimination is a double-elimination bracket: players drop into a losers bracket after their first loss
+// and are eliminated after their second. The winners bracket champion meets the losers bracket champion in the
+// grand final, which is followed by a reset game if the losers bracket champion wins it (see WithoutGrandFinalReset).
+type DoubleElimination struct {
+	// winners and losers hold the pairings of every round of the winners and losers brackets.
+	winners, losers [][]*bracketNode
+
+	// grandFinal and reset are the grand final and its optional reset game.
+	grandFinal, reset *bracketNode
+
+	// order lists every pairing in playing order.
+	order []*bracketNode
+
+	// champion is the winner of the tournament, nil until it is decided.
+	champion *player.Player
+
+	// seeds maps every player to their 0-based seed (roster position).
+	seeds map[*player.Player]int
+
+	// config holds the seed and match options of the tournament.
+	config *config
+}
+
+// NewDoubleElimination creates a double-elimination bracket for the given roster. Players are seeded into the winners
+// bracket as in NewSingleElimination, with byes for the top seeds when the roster size is not a power of two.
+//
+// Parameters:
+//   - roster: The players in the tournament, in seed order.
+//   - opts: Optional settings such as WithSeed, WithMatchOptions or WithoutGrandFinalReset.
+//
+// Returns:
+//   - *DoubleElimination: A pointer to the newly created bracket.
+//   - error: ErrTooFewPlayers if the roster has fewer than two players, ErrDuplicatePlayer if a player is listed twice.
+//
+// Example:
+//   bracket, err := NewDoubleElimination(roster, WithSeed(42))
+//   champion := bracket.Run()
+func NewDoubleElimination(roster []*player.Player, opts ...Option) (*DoubleElimination, error) {
+	if err := checkRoster(roster); err != nil {
+		return nil, err
+	}
+
+	de := &DoubleElimination{seeds: seedMap(roster), config: newConfig(opts)}
+	order := bracketOrder(len(roster))
+	size := len(order)
+
+	//winners bracket: halving rounds, each winner moving to the next round
+	for pairings := size / 2; pairings >= 1; pairings /= 2 {
+		de.winners = append(de.winners, newRound(len(de.winners), pairings))
+	}
+	for r := 0; r+1 < len(de.winners); r++ {
+		for i, node := range de.winners[r] {
+			node.winnerTo, node.winnerSlot = de.winners[r+1][i/2], i%2
+		}
+	}
+
+	//losers bracket: the losers of the first round meet each other, then every pair of rounds takes in
+	//the losers of the next winners round and halves the field
+	de.grandFinal = &bracketNode{Pairing: Pairing{Round: 0}}
+	de.reset = &bracketNode{Pairing: Pairing{Round: 1}}
+	wbFinal := de.winners[len(de.winners)-1][0]
+	wbFinal.winnerTo, wbFinal.winnerSlot = de.grandFinal, 0
+	if len(de.winners) == 1 {
+		wbFinal.loserTo, wbFinal.loserSlot = de.grandFinal, 1
+	} else {
+		de.losers = append(de.losers, newRound(0, size/4))
+		for i, node := range de.winners[0] {
+			node.loserTo, node.loserSlot = de.losers[0][i/2], i%2
+		}
+		for m := 1; m < len(de.winners); m++ {
+			//drop-in round: survivors of the losers bracket meet the losers of winners round m, in reverse order to delay rematches
+			previous := de.losers[len(de.losers)-1]
+			dropIn := newRound(len(de.losers), len(previous))
+			for i, node := range previous {
+				node.winnerTo, node.winnerSlot = dropIn[i], 0
+			}
+			for i, node := range de.winners[m] {
+				node.loserTo, node.loserSlot = dropIn[len(dropIn)-1-i], 1
+			}
+			de.losers = append(de.losers, dropIn)
+
+			if len(dropIn) == 1 {
+				dropIn[0].winnerTo, dropIn[0].winnerSlot = de.grandFinal, 1
+				break
+			}
+
+			//halving round: survivors of the drop-in round meet each other
+			halving := newRound(len(de.losers), len(dropIn)/2)
+			for i, node := range dropIn {
+				node.winnerTo, node.winnerSlot = halving[i/2], i%2
+			}
+			de.losers = append(de.losers, halving)
+		}
+	}
+
+	//playing order: each winners round, then the losers rounds that only depend on rounds already played
+	de.order = append(de.order, de.winners[0]...)
+	for m := 1; m < len(de.winners); m++ {
+		de.order = append(de.order, de.winners[m]...)
+		for _, round := range de.losers {
+			if round[0].Round <= 2*m-1 && round[0].Round >= 2*m-2 {
+				de.order = append(de.order, round...)
+			}
+		}
+	}
+	de.order = append(de.order, de.grandFinal)
+
+	//placing the seeds into the first round, with empty slots for seeds that do not exist
+	for i, node := range de.winners[0] {
+		de.fill(node, 0, roster[order[2*i]])
+		var opponent *player.Player
+		if seed := order[2*i+1]; seed < len(roster) {
+			opponent = roster[seed]
+		}
+		de.fill(node, 1, opponent)
+	}
+	return de, nil
+}
+
+// newRound creates a round of empty bracket pairings.
+func newRound(round, pairings int) []*bracketNode {
+	nodes := make([]*bracketNode, pairings)
+	for i := range nodes {
+		nodes[i] = &bracketNode{Pairing: Pairing{Round: round, Position: i}}
+	}
+	return nodes
+}
+
+// fill puts a player (or nil for an empty slot) into a slot of a pairing, and resolves the pairing
+// without a game when one or both of its slots turn out to be empty.
+func (de *DoubleElimination) fill(node *bracketNode, slot int, p *player.Player) {
+	if slot == 0 {
+		node.PlayerA, node.resolvedA = p, true
+	} else {
+		node.PlayerB, node.resolvedB = p, true
+	}
+	if !node.resolvedA || !node.resolvedB || (node.PlayerA != nil && node.PlayerB != nil) {
+		return
+	}
+
+	//a bye: the only player (if any) advances and nobody drops down
+	winner := node.PlayerA
+	if winner == nil {
+		winner = node.PlayerB
+	}
+	node.Bye = winner != nil
+	if node.PlayerA == nil && winner != nil {
+		node.PlayerA, node.PlayerB = winner, nil
+	}
+	de.decide(node, winner, nil)
+}
+
+// decide records the winner of a pairing and moves the winner and loser on through the bracket.
+func (de *DoubleElimination) decide(node *bracketNode, winner, loser *player.Player) {
+	node.Winner, node.done = winner, true
+	if node == de.grandFinal && winner == node.PlayerB && !de.config.noGrandFinalReset {
+		//the losers bracket champion handed the winners bracket champion their first loss
+		de.order = append(de.order, de.reset)
+		de.fill(de.reset, 0, node.PlayerA)
+		de.fill(de.reset, 1, node.PlayerB)
+		return
+	}
+	if node == de.grandFinal || node == de.reset {
+		de.champion = winner
+		return
+	}
+	if node.winnerTo != nil {
+		de.fill(node.winnerTo, node.winnerSlot, winner)
+	}
+	if node.loserTo != nil {
+		de.fill(node.loserTo, node.loserSlot, loser)
+	}
+}
+
+// PlayNext plays the next pairing of the bracket.
+//
+// Returns:
+//   - Pairing: The pairing that was played, including its game and winner.
+//   - error: ErrTournamentOver if the champion has already been decided.
+//
+// Example:
+//   for !bracket.IsOver() {
+//       pairing, _ := bracket.PlayNext()
+//       fmt.Println(pairing.Game.Result)
+//   }
+func (de *DoubleElimination) PlayNext() (Pairing, error) {
+	for _, node := range de.order {
+		if node.done || !node.resolvedA || !node.resolvedB {
+			continue
+		}
+		node.Game = de.config.play(node.PlayerA, node.PlayerB)
+		winner := decide(node.Game, de.seeds)
+		loser := node.PlayerA
+		if winner == node.PlayerA {
+			loser = node.PlayerB
+		}
+		de.decide(node, winner, loser)
+		return node.Pairing, nil
+	}
+	return Pairing{}, ErrTournamentOver
+}
+
+// Run plays every remaining pairing of the bracket and returns the champion.
+//
+// Returns:
+//   - *player.Player: The champion of the tournament.
+func (de *DoubleElimination) Run() *player.Player {
+	for !de.IsOver() {
+		de.PlayNext()
+	}
+	return de.champion
+}
+
+// IsOver reports whether the champion has been decided.
+func (de *DoubleElimination) IsOver() bool {
+	return de.champion != nil
+}
+
+// Champion returns the winner of the tournament, or nil until it has been decided.
+func (de *DoubleElimination) Champion() *player.Player {
+	return de.champion
+}
+
+// WinnersBracket returns a snapshot of the pairings of every round of the winners bracket.
+func (de *DoubleElimination) WinnersBracket() [][]Pairing {
+	return snapshot(de.winners)
+}
+
+// LosersBracket returns a snapshot of the pairings of every round of the losers bracket.
+func (de *DoubleElimination) LosersBracket() [][]Pairing {
+	return snapshot(de.losers)
+}
+
+// GrandFinal returns a snapshot of the grand final, followed by the reset game once it has been scheduled.
+func (de *DoubleElimination) GrandFinal() []Pairing {
+	finals := []Pairing{de.grandFinal.Pairing}
+	if de.reset.resolvedA {
+		finals = append(finals, de.reset.Pairing)
+	}
+	return finals
+}
+
+// snapshot copies the pairings of a set of bracket rounds.
+func snapshot(rounds [][]*bracketNode) [][]Pairing {
+	pairings := make([][]Pairing, len(rounds))
+	for r, round := range rounds {
+		pairings[r] = make([]Pairing, len(round))
+		for i, node := range round {
+			pairings[r][i] = node.Pairing
+		}
+	}
+	return pairings
+}
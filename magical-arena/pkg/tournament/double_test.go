@@ -0,0 +1,69 @@
+package tournament
+
+import (
+	"fmt"
+	"magical-arena/pkg/player"
+	"testing"
+)
+
+// TestDoubleElimination tests that every player except the champion is eliminated after exactly two losses.
+func TestDoubleElimination(t *testing.T) {
+	for test, size := range []int{2, 5, 6, 8} {
+		// TEST n: for rosters of 2, 5, 6 and 8 players, every game has a loser, eliminated players lost exactly twice,
+		// the champion lost at most once, and 2(n-1) games are played, plus one if the grand final was reset
+		roster := newRoster(size)
+		bracket, _ := NewDoubleElimination(roster, WithSeed(int64(size)))
+		games := 0
+		losses := make(map[*player.Player]int)
+		for !bracket.IsOver() {
+			pairing, err := bracket.PlayNext()
+			if err != nil {
+				t.Fatalf(redColor+"Unexpected error %v"+resetColor, err)
+			}
+			games++
+			if pairing.Winner == pairing.PlayerA {
+				losses[pairing.PlayerB]++
+			} else {
+				losses[pairing.PlayerA]++
+			}
+		}
+		champion := bracket.Champion()
+		finals := bracket.GrandFinal()
+		ok := champion != nil && losses[champion] <= 1 && games == 2*(size-1)+len(finals)-1
+		for _, p := range roster {
+			if p != champion && losses[p] != 2 {
+				ok = false
+			}
+		}
+		if !ok {
+			t.Errorf(redColor+"Size %d: unexpected losses %v after %d games"+resetColor, size, losses, games)
+		} else {
+			fmt.Printf(greenColor+"TestDoubleElimination : Test%d : Passed"+resetColor+"\n", test+1)
+		}
+	}
+
+	// TEST 5: without a reset, the grand final is always a single game
+	bracket, _ := NewDoubleElimination(newRoster(4), WithSeed(3), WithoutGrandFinalReset())
+	bracket.Run()
+	winners, losers := bracket.WinnersBracket(), bracket.LosersBracket()
+	if len(bracket.GrandFinal()) != 1 || len(winners) != 2 || len(losers) != 2 || bracket.Champion() != bracket.GrandFinal()[0].Winner {
+		t.Errorf(redColor+"Unexpected bracket shape %d/%d/%d"+resetColor, len(winners), len(losers), len(bracket.GrandFinal()))
+	} else {
+		fmt.Println(greenColor + "TestDoubleElimination : Test5 : Passed" + resetColor)
+	}
+
+	// TEST 6: the grand final is reset when the losers bracket champion wins it.
+	// The players are evenly matched, so one of the first 50 seeds leads to a reset.
+	reset := false
+	for seed := int64(0); seed < 50 && !reset; seed++ {
+		bracket, _ = NewDoubleElimination(newRoster(4), WithSeed(seed))
+		bracket.Run()
+		finals := bracket.GrandFinal()
+		reset = len(finals) == 2 && finals[0].Winner == finals[0].PlayerB && finals[1].Winner == bracket.Champion()
+	}
+	if !reset {
+		t.Errorf(redColor+"Expected a grand final reset for some seed"+resetColor)
+	} else {
+		fmt.Println(greenColor + "TestDoubleElimination : Test6 : Passed" + resetColor)
+	}
+}
@@ -0,0 +1,207 @@
+package tournament
+
+import (
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+)
+
+// Pairing is a slot in an elimination bracket: two players meeting in a given round, and the winner who advances.
+type Pairing struct {
+	// Round is the 0-based round of the bracket.
+	Round int
+
+	// Position is the 0-based position of the pairing within its round.
+	Position int
+
+	// PlayerA is the first player of the pairing, or nil while it is not yet known.
+	PlayerA *player.Player
+
+	// PlayerB is the second player of the pairing, or nil while it is not yet known or for a bye.
+	PlayerB *player.Player
+
+	// Bye reports whether PlayerA advances without a match because there is no opponent.
+	Bye bool
+
+	// Winner is the player advancing from the pairing, or nil until it is decided.
+	Winner *player.Player
+
+	// Game is the match played for the pairing, or nil for a bye or a pairing not yet played.
+	Game *Game
+}
+
+// ready reports whether the pairing has both players and still needs to be played.
+func (p *Pairing) ready() bool {
+	return p.Winner == nil && p.PlayerA != nil && p.PlayerB != nil
+}
+
+// SingleElimination is a single-elimination bracket. Players are seeded in roster order, the bracket is padded
+// to the next power of two with byes for the top seeds, and the winner of every match advances to the next round.
+type SingleElimination struct {
+	// rounds holds the pairings of every round, from the first round to the final.
+	rounds [][]*Pairing
+
+	// seeds maps every player to their 0-based seed (roster position).
+	seeds map[*player.Player]int
+
+	// config holds the seed and match options of the tournament.
+	config *config
+}
+
+// NewSingleElimination creates a single-elimination bracket for the given roster.
+// The first player in the roster is the top seed; seeds are placed so that the top seeds can only meet in the late rounds,
+// and when the roster size is not a power of two, the top seeds receive byes in the first round.
+//
+// Parameters:
+//   - roster: The players in the tournament, in seed order.
+//   - opts: Optional settings such as WithSeed or WithMatchOptions.
+//
+// Returns:
+//   - *SingleElimination: A pointer to the newly created bracket.
+//   - error: ErrTooFewPlayers if the roster has fewer than two players, ErrDuplicatePlayer if a player is listed twice.
+//
+// Example:
+//   bracket, err := NewSingleElimination(roster, WithSeed(42))
+//   champion := bracket.Run()
+func NewSingleElimination(roster []*player.Player, opts ...Option) (*SingleElimination, error) {
+	if err := checkRoster(roster); err != nil {
+		return nil, err
+	}
+
+	se := &SingleElimination{seeds: seedMap(roster), config: newConfig(opts)}
+	order := bracketOrder(len(roster))
+	for size := len(order) / 2; size >= 1; size /= 2 {
+		pairings := make([]*Pairing, size)
+		for i := range pairings {
+			pairings[i] = &Pairing{Round: len(se.rounds), Position: i}
+		}
+		se.rounds = append(se.rounds, pairings)
+	}
+
+	//placing the seeds into the first round, giving byes where the opponent seed does not exist
+	for i, pairing := range se.rounds[0] {
+		pairing.PlayerA = roster[order[2*i]]
+		if seed := order[2*i+1]; seed < len(roster) {
+			pairing.PlayerB = roster[seed]
+		} else {
+			pairing.Bye = true
+			se.advance(pairing, pairing.PlayerA)
+		}
+	}
+	return se, nil
+}
+
+// seedMap maps every player of a roster to their 0-based position.
+func seedMap(roster []*player.Player) map[*player.Player]int {
+	seeds := make(map[*player.Player]int, len(roster))
+	for i, p := range roster {
+		seeds[p] = i
+	}
+	return seeds
+}
+
+// bracketOrder returns the 0-based seeds of a bracket for n players in first-round order,
+// padded to the next power of two. Consecutive entries meet in the first round, e.g. for 8 players:
+// [0 7 3 4 1 6 2 5], so seed 0 meets seed 7 and can only meet seed 1 in the final.
+func bracketOrder(n int) []int {
+	order := []int{0}
+	for len(order) < n {
+		size := 2 * len(order)
+		next := make([]int, 0, size)
+		for _, seed := range order {
+			next = append(next, seed, size-1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// advance records the winner of a pairing and moves them into their slot in the next round.
+func (se *SingleElimination) advance(pairing *Pairing, winner *player.Player) {
+	pairing.Winner = winner
+	if pairing.Round+1 == len(se.rounds) {
+		return
+	}
+	next := se.rounds[pairing.Round+1][pairing.Position/2]
+	if pairing.Position%2 == 0 {
+		next.PlayerA = winner
+	} else {
+		next.PlayerB = winner
+	}
+}
+
+// decide returns the winner of a game. When the game did not produce a winner (a draw or a stalemate),
+// the higher seed advances.
+func decide(game *Game, seeds map[*player.Player]int) *player.Player {
+	if game.Result.Outcome == match.Win {
+		return game.Result.Winner
+	}
+	if seeds[game.PlayerB] < seeds[game.PlayerA] {
+		return game.PlayerB
+	}
+	return game.PlayerA
+}
+
+// PlayNext plays the next pairing of the bracket, in round order.
+//
+// Returns:
+//   - Pairing: The pairing that was played, including its game and winner.
+//   - error: ErrTournamentOver if the bracket is already complete.
+//
+// Example:
+//   for !bracket.IsOver() {
+//       pairing, _ := bracket.PlayNext()
+//       fmt.Println(pairing.Game.Result)
+//   }
+func (se *SingleElimination) PlayNext() (Pairing, error) {
+	for _, round := range se.rounds {
+		for _, pairing := range round {
+			if pairing.ready() {
+				pairing.Game = se.config.play(pairing.PlayerA, pairing.PlayerB)
+				se.advance(pairing, decide(pairing.Game, se.seeds))
+				return *pairing, nil
+			}
+		}
+	}
+	return Pairing{}, ErrTournamentOver
+}
+
+// Run plays every remaining pairing of the bracket and returns the champion.
+//
+// Returns:
+//   - *player.Player: The champion of the tournament.
+func (se *SingleElimination) Run() *player.Player {
+	for !se.IsOver() {
+		se.PlayNext()
+	}
+	return se.Champion()
+}
+
+// IsOver reports whether the final has been decided.
+func (se *SingleElimination) IsOver() bool {
+	return se.Champion() != nil
+}
+
+// Champion returns the winner of the final, or nil until it has been played.
+func (se *SingleElimination) Champion() *player.Player {
+	return se.rounds[len(se.rounds)-1][0].Winner
+}
+
+// Rounds returns a snapshot of the whole bracket: the pairings of every round, from the first round to the final.
+//
+// Returns:
+//   - [][]Pairing: The pairings of every round.
+//
+// Example:
+//   for r, round := range bracket.Rounds() {
+//       fmt.Printf("Round %d: %d pairings\n", r+1, len(round))
+//   }
+func (se *SingleElimination) Rounds() [][]Pairing {
+	rounds := make([][]Pairing, len(se.rounds))
+	for r, round := range se.rounds {
+		rounds[r] = make([]Pairing, len(round))
+		for i, pairing := range round {
+			rounds[r][i] = *pairing
+		}
+	}
+	return rounds
+}
@@ -0,0 +1,111 @@
+package tournament
+
+import (
+	"fmt"
+	"magical-arena/pkg/player"
+	"os"
+	"testing"
+)
+
+// ANSI escape codes for text color
+const (
+	redColor   = "\033[31m"
+	greenColor = "\033[32m"
+	resetColor = "\033[0m"
+)
+
+// newRoster creates n evenly matched players named Player1..PlayerN.
+func newRoster(n int) []*player.Player {
+	roster := make([]*player.Player, n)
+	for i := range roster {
+		roster[i] = player.NewPlayer(fmt.Sprintf("Player%d", i+1), 50, 5, 10)
+	}
+	return roster
+}
+
+// TestBracketOrder tests the placement of seeds in the first round.
+func TestBracketOrder(t *testing.T) {
+	// TEST 1: 8 players are placed so that seed 0 meets seed 7 and seeds 0 and 1 are in opposite halves
+	order := bracketOrder(8)
+	if fmt.Sprint(order) != "[0 7 3 4 1 6 2 5]" {
+		t.Errorf(redColor+"Expected [0 7 3 4 1 6 2 5], got %v"+resetColor, order)
+	} else {
+		fmt.Println(greenColor + "TestBracketOrder : Test1 : Passed" + resetColor)
+	}
+}
+
+// TestSingleElimination tests seeding, byes and advancement in a single-elimination bracket.
+func TestSingleElimination(t *testing.T) {
+	// TEST 1: a roster of one player, or with a player listed twice, is rejected by every kind of tournament
+	duplicated := newRoster(3)
+	duplicated = append(duplicated, duplicated[1])
+	_, singleErr := NewSingleElimination(duplicated)
+	_, doubleErr := NewDoubleElimination(duplicated)
+	_, leagueErr := NewLeague(duplicated)
+	_, swissErr := NewSwiss(duplicated, 2)
+	if _, err := NewSingleElimination(newRoster(1)); err != ErrTooFewPlayers {
+		t.Errorf(redColor+"Expected ErrTooFewPlayers, got %v"+resetColor, err)
+	} else if singleErr != ErrDuplicatePlayer || doubleErr != ErrDuplicatePlayer || leagueErr != ErrDuplicatePlayer || swissErr != ErrDuplicatePlayer {
+		t.Errorf(redColor+"Expected ErrDuplicatePlayer, got %v, %v, %v, %v"+resetColor, singleErr, doubleErr, leagueErr, swissErr)
+	} else {
+		fmt.Println(greenColor + "TestSingleElimination : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: 5 players make a 3 round bracket where the top 3 seeds get byes
+	roster := newRoster(5)
+	bracket, _ := NewSingleElimination(roster, WithSeed(3))
+	rounds := bracket.Rounds()
+	byes := 0
+	for _, pairing := range rounds[0] {
+		if pairing.Bye {
+			byes++
+			if pairing.Winner != pairing.PlayerA || pairing.PlayerA != roster[0] && pairing.PlayerA != roster[1] && pairing.PlayerA != roster[2] {
+				t.Errorf(redColor+"Unexpected bye %+v"+resetColor, pairing)
+			}
+		}
+	}
+	if len(rounds) != 3 || len(rounds[0]) != 4 || byes != 3 {
+		t.Errorf(redColor+"Expected 3 rounds with 3 byes, got %d rounds with %d byes"+resetColor, len(rounds), byes)
+	} else {
+		fmt.Println(greenColor + "TestSingleElimination : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: every winner plays in the next round and the final winner is the champion
+	champion := bracket.Run()
+	rounds = bracket.Rounds()
+	advanced := true
+	for r := 0; r+1 < len(rounds); r++ {
+		for i, pairing := range rounds[r] {
+			next := rounds[r+1][i/2]
+			if pairing.Winner == nil || (pairing.Winner != next.PlayerA && pairing.Winner != next.PlayerB) {
+				advanced = false
+			}
+		}
+	}
+	if !advanced || champion == nil || champion != rounds[2][0].Winner || !bracket.IsOver() {
+		t.Errorf(redColor+"Expected winners to advance to a champion, got %+v"+resetColor, rounds)
+	} else {
+		fmt.Println(greenColor + "TestSingleElimination : Test3 : Passed" + resetColor)
+	}
+
+	// TEST 4: a player who survives any single hit and always wins in one hit becomes champion, and the bracket is then over
+	roster = newRoster(6)
+	roster[4] = player.NewPlayer("Champion", 100, 5, 100)
+	bracket, _ = NewSingleElimination(roster, WithSeed(1))
+	_, err := bracket.PlayNext()
+	champion = bracket.Run()
+	_, errOver := bracket.PlayNext()
+	if err != nil || champion != roster[4] || errOver != ErrTournamentOver {
+		t.Errorf(redColor+"Expected Champion to win, got %v (errors %v, %v)"+resetColor, champion, err, errOver)
+	} else {
+		fmt.Println(greenColor + "TestSingleElimination : Test4 : Passed" + resetColor)
+	}
+}
+
+// TestMain runs the main testing suite.
+func TestMain(m *testing.M) {
+	fmt.Println("Testing tournament package...")
+	Result := m.Run()
+	fmt.Println("Testing complete.")
+	os.Exit(Result)
+}
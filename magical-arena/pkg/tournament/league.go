@@ -0,0 +1,263 @@
+package tournament
+
+import (
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"sort"
+)
+
+// Points awarded for league results. A stalemate or an aborted match counts as a draw.
+const (
+	PointsForWin  = 3
+	PointsForDraw = 1
+	PointsForLoss = 0
+)
+
+// Standing is a player's line in the league table.
+type Standing struct {
+	// Player is a pointer to the player.
+	Player *player.Player
+
+	// Played is the number of games played.
+	Played int
+
+	// Wins is the number of games won.
+	Wins int
+
+	// Losses is the number of games lost.
+	Losses int
+
+	// Draws is the number of games without a winner.
+	Draws int
+
+	// Points is the total of league points: PointsForWin per win and PointsForDraw per draw.
+	Points int
+
+	// DamageDealt is the total damage the player dealt.
+	DamageDealt int
+
+	// DamageReceived is the total damage the player received.
+	DamageReceived int
+}
+
+// TieBreaker compares two players who are level on points. It returns a negative number if a ranks above b,
+// a positive number if b ranks above a, and 0 if the tie-breaker cannot separate them.
+type TieBreaker func(a, b Standing) int
+
+// MostWins ranks the player with more wins higher.
+func MostWins(a, b Standing) int {
+	return b.Wins - a.Wins
+}
+
+// DamageDifference ranks the player with the larger difference between damage dealt and damage received higher.
+func DamageDifference(a, b Standing) int {
+	return (b.DamageDealt - b.DamageReceived) - (a.DamageDealt - a.DamageReceived)
+}
+
+// MostDamageDealt ranks the player who dealt more damage higher.
+func MostDamageDealt(a, b Standing) int {
+	return b.DamageDealt - a.DamageDealt
+}
+
+// FewestDamageReceived ranks the player who received less damage higher.
+func FewestDamageReceived(a, b Standing) int {
+	return a.DamageReceived - b.DamageReceived
+}
+
+// DefaultTieBreakers are the tie-breakers used unless WithTieBreakers says otherwise.
+var DefaultTieBreakers = []TieBreaker{MostWins, DamageDifference, MostDamageDealt}
+
+// WithHomeAndAway makes every pair of players in a league meet twice, each attacking first in one of the two games.
+//
+// Returns:
+//   - Option: An option to pass to NewLeague.
+//
+// Example:
+//   league, err := NewLeague(roster, WithHomeAndAway())
+func WithHomeAndAway() Option {
+	return func(c *config) {
+		c.homeAndAway = true
+	}
+}
+
+// WithTieBreakers sets the tie-breakers that rank league players who are level on points, in order of priority.
+// Players still level after every tie-breaker keep their roster order.
+//
+// Parameters:
+//   - tieBreakers: The tie-breakers, such as MostWins or DamageDifference.
+//
+// Returns:
+//   - Option: An option to pass to NewLeague.
+//
+// Example:
+//   league, err := NewLeague(roster, WithTieBreakers(DamageDifference, MostWins))
+func WithTieBreakers(tieBreakers ...TieBreaker) Option {
+	return func(c *config) {
+		c.tieBreakers = tieBreakers
+	}
+}
+
+// Fixture is a scheduled league game between a home and an away player.
+type Fixture struct {
+	// Home is the home player, who is PlayerA of the match.
+	Home *player.Player
+
+	// Away is the away player, who is PlayerB of the match.
+	Away *player.Player
+
+	// Game is the match played for the fixture, or nil until it is played.
+	Game *Game
+}
+
+// League is a round-robin competition where every player fights every other player, with a standings table.
+type League struct {
+	// roster holds the players in roster order.
+	roster []*player.Player
+
+	// fixtures holds every game of the league, in playing order.
+	fixtures []*Fixture
+
+	// standings maps every player to their line in the table.
+	standings map[*player.Player]*Standing
+
+	// config holds the seed, match options and league settings.
+	config *config
+}
+
+// NewLeague creates a round-robin league for the given roster. By default every pair of players meets once and
+// the normal starting-player rule applies; with WithHomeAndAway every pair meets twice and the home player attacks first.
+//
+// Parameters:
+//   - roster: The players in the league.
+//   - opts: Optional settings such as WithSeed, WithMatchOptions, WithHomeAndAway or WithTieBreakers.
+//
+// Returns:
+//   - *League: A pointer to the newly created league.
+//   - error: ErrTooFewPlayers if the roster has fewer than two players, ErrDuplicatePlayer if a player is listed twice.
+//
+// Example:
+//   league, err := NewLeague(roster, WithSeed(42), WithHomeAndAway())
+//   table := league.Run()
+func NewLeague(roster []*player.Player, opts ...Option) (*League, error) {
+	if err := checkRoster(roster); err != nil {
+		return nil, err
+	}
+
+	league := &League{roster: roster, standings: make(map[*player.Player]*Standing), config: newConfig(opts)}
+	for _, p := range roster {
+		league.standings[p] = &Standing{Player: p}
+	}
+
+	//scheduling every pair once, then the return games with home and away swapped
+	for i := range roster {
+		for j := i + 1; j < len(roster); j++ {
+			league.fixtures = append(league.fixtures, &Fixture{Home: roster[i], Away: roster[j]})
+		}
+	}
+	if league.config.homeAndAway {
+		firstLeg := league.fixtures
+		for _, fixture := range firstLeg {
+			league.fixtures = append(league.fixtures, &Fixture{Home: fixture.Away, Away: fixture.Home})
+		}
+	}
+	return league, nil
+}
+
+// Fixtures returns a snapshot of every fixture of the league, in playing order.
+func (l *League) Fixtures() []Fixture {
+	fixtures := make([]Fixture, len(l.fixtures))
+	for i, fixture := range l.fixtures {
+		fixtures[i] = *fixture
+	}
+	return fixtures
+}
+
+// IsOver reports whether every fixture has been played.
+func (l *League) IsOver() bool {
+	return l.fixtures[len(l.fixtures)-1].Game != nil
+}
+
+// PlayNext plays the next fixture and updates the standings.
+//
+// Returns:
+//   - Fixture: The fixture that was played, including its game.
+//   - error: ErrTournamentOver if every fixture has already been played.
+func (l *League) PlayNext() (Fixture, error) {
+	for _, fixture := range l.fixtures {
+		if fixture.Game != nil {
+			continue
+		}
+		var extra []match.Option
+		if l.config.homeAndAway {
+			extra = append(extra, match.WithFirstAttacker(fixture.Home))
+		}
+		fixture.Game = l.config.play(fixture.Home, fixture.Away, extra...)
+		l.record(fixture.Game)
+		return *fixture, nil
+	}
+	return Fixture{}, ErrTournamentOver
+}
+
+// record adds the result of a game to the standings.
+func (l *League) record(game *Game) {
+	home, away := l.standings[game.PlayerA], l.standings[game.PlayerB]
+	home.Played++
+	away.Played++
+	home.DamageDealt += game.DamageA
+	home.DamageReceived += game.DamageB
+	away.DamageDealt += game.DamageB
+	away.DamageReceived += game.DamageA
+
+	switch {
+	case game.Result.Outcome != match.Win:
+		home.Draws++
+		away.Draws++
+	case game.Result.Winner == game.PlayerA:
+		home.Wins++
+		away.Losses++
+	default:
+		away.Wins++
+		home.Losses++
+	}
+	home.Points = home.Wins*PointsForWin + home.Draws*PointsForDraw + home.Losses*PointsForLoss
+	away.Points = away.Wins*PointsForWin + away.Draws*PointsForDraw + away.Losses*PointsForLoss
+}
+
+// Run plays every remaining fixture and returns the final standings.
+//
+// Returns:
+//   - []Standing: The league table, from first to last place.
+func (l *League) Run() []Standing {
+	for !l.IsOver() {
+		l.PlayNext()
+	}
+	return l.Standings()
+}
+
+// Standings returns the current league table, ordered by points and then by the league's tie-breakers.
+//
+// Returns:
+//   - []Standing: The league table, from first to last place.
+//
+// Example:
+//   for place, standing := range league.Standings() {
+//       fmt.Printf("%d. %d points\n", place+1, standing.Points)
+//   }
+func (l *League) Standings() []Standing {
+	table := make([]Standing, len(l.roster))
+	for i, p := range l.roster {
+		table[i] = *l.standings[p]
+	}
+	sort.SliceStable(table, func(i, j int) bool {
+		if table[i].Points != table[j].Points {
+			return table[i].Points > table[j].Points
+		}
+		for _, tieBreaker := range l.config.tieBreakers {
+			if c := tieBreaker(table[i], table[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	return table
+}
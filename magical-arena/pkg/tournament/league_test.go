@@ -0,0 +1,57 @@
+package tournament
+
+import (
+	"fmt"
+	"magical-arena/pkg/player"
+	"testing"
+)
+
+// TestLeague tests fixtures, standings and tie-breakers of a round-robin league.
+func TestLeague(t *testing.T) {
+	// TEST 1: 4 players play 6 fixtures, or 12 home and away with each player attacking first at home
+	roster := newRoster(4)
+	league, _ := NewLeague(roster, WithSeed(5))
+	homeAndAway, _ := NewLeague(roster, WithSeed(5), WithHomeAndAway())
+	fixtures := homeAndAway.Fixtures()
+	if len(league.Fixtures()) != 6 || len(fixtures) != 12 || fixtures[6].Home != fixtures[0].Away || fixtures[6].Away != fixtures[0].Home {
+		t.Errorf(redColor+"Expected 6 and 12 fixtures, got %d and %d"+resetColor, len(league.Fixtures()), len(fixtures))
+	} else {
+		fmt.Println(greenColor + "TestLeague : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: every player plays 6 home and away games and the table is ordered by points
+	table := homeAndAway.Run()
+	wins, losses := 0, 0
+	for i, standing := range table {
+		wins += standing.Wins
+		losses += standing.Losses
+		if standing.Played != 6 || (i > 0 && standing.Points > table[i-1].Points) {
+			t.Errorf(redColor+"Unexpected standings %+v"+resetColor, table)
+			break
+		}
+	}
+	if wins != losses || !homeAndAway.IsOver() {
+		t.Errorf(redColor+"Expected wins and losses to balance, got %d and %d"+resetColor, wins, losses)
+	} else {
+		fmt.Println(greenColor + "TestLeague : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: a player who always wins in one hit tops the table with every win
+	roster[2] = player.NewPlayer("Champion", 100, 5, 100)
+	league, _ = NewLeague(roster, WithSeed(1))
+	table = league.Run()
+	if table[0].Player != roster[2] || table[0].Wins != 3 || table[0].Points != 3*PointsForWin || table[0].DamageReceived >= 100 {
+		t.Errorf(redColor+"Expected Champion to top the table, got %+v"+resetColor, table[0])
+	} else {
+		fmt.Println(greenColor + "TestLeague : Test3 : Passed" + resetColor)
+	}
+
+	// TEST 4: tie-breakers order players level on points
+	a := Standing{Points: 3, Wins: 1, DamageDealt: 50, DamageReceived: 10}
+	b := Standing{Points: 3, Wins: 1, DamageDealt: 60, DamageReceived: 40}
+	if DamageDifference(a, b) >= 0 || MostDamageDealt(a, b) <= 0 || FewestDamageReceived(a, b) >= 0 || MostWins(a, b) != 0 {
+		t.Errorf(redColor+"Unexpected tie-breaker results"+resetColor)
+	} else {
+		fmt.Println(greenColor + "TestLeague : Test4 : Passed" + resetColor)
+	}
+}
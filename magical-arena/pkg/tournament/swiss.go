@@ -0,0 +1,332 @@
+package tournament
+
+import (
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"sort"
+)
+
+// Scores awarded in a Swiss tournament. A bye scores as a win; a stalemate or an aborted match counts as a draw.
+const (
+	SwissWinScore  = 1.0
+	SwissDrawScore = 0.5
+	SwissByeScore  = 1.0
+)
+
+// maxPairingSteps bounds the search for a round without rematches, which would otherwise take factorial time
+// when no such round exists.
+const maxPairingSteps = 10000
+
+// SwissPairing is a game of a Swiss tournament round, or a bye.
+type SwissPairing struct {
+	// Round is the 0-based round of the tournament.
+	Round int
+
+	// PlayerA is the first player of the pairing.
+	PlayerA *player.Player
+
+	// PlayerB is the second player of the pairing, or nil for a bye.
+	PlayerB *player.Player
+
+	// Game is the match played for the pairing, or nil for a bye.
+	Game *Game
+}
+
+// SwissStanding is a player's line in the Swiss standings.
+type SwissStanding struct {
+	// Player is a pointer to the player.
+	Player *player.Player
+
+	// Score is the player's total score.
+	Score float64
+
+	// Wins is the number of games won, not counting byes.
+	Wins int
+
+	// Losses is the number of games lost.
+	Losses int
+
+	// Draws is the number of games without a winner.
+	Draws int
+
+	// Byes is the number of byes received.
+	Byes int
+
+	// Buchholz is the sum of the scores of the player's opponents.
+	Buchholz float64
+
+	// BuchholzCut1 is Buchholz without the lowest opponent score.
+	BuchholzCut1 float64
+}
+
+// Swiss is a Swiss-system tournament: in every round players are paired with opponents on equal or similar scores
+// whom they have not met before, for a fixed number of rounds.
+type Swiss struct {
+	// roster holds the players in roster order.
+	roster []*player.Player
+
+	// rounds is the number of rounds of the tournament.
+	rounds int
+
+	// pairings holds the pairings of every round played.
+	pairings [][]SwissPairing
+
+	// standings maps every player to their line in the standings, without tie-breaks.
+	standings map[*player.Player]*SwissStanding
+
+	// opponents maps every player to the opponents they have met.
+	opponents map[*player.Player][]*player.Player
+
+	// config holds the seed and match options of the tournament.
+	config *config
+}
+
+// NewSwiss creates a Swiss tournament for the given roster.
+//
+// Parameters:
+//   - roster: The players in the tournament, in seed order.
+//   - rounds: The number of rounds to play, at most len(roster)-1. A value of 0 or less means ceil(log2(len(roster))) rounds.
+//   - opts: Optional settings such as WithSeed or WithMatchOptions.
+//
+// Returns:
+//   - *Swiss: A pointer to the newly created tournament.
+//   - error: ErrTooFewPlayers if the roster has fewer than two players, ErrDuplicatePlayer if a player is listed twice,
+//     ErrTooManyRounds if rounds is more than len(roster)-1.
+//
+// Example:
+//   swiss, err := NewSwiss(roster, 5, WithSeed(42))
+//   standings := swiss.Run()
+func NewSwiss(roster []*player.Player, rounds int, opts ...Option) (*Swiss, error) {
+	if err := checkRoster(roster); err != nil {
+		return nil, err
+	}
+	if rounds > len(roster)-1 {
+		return nil, ErrTooManyRounds
+	}
+	if rounds <= 0 {
+		rounds = 0
+		for 1<<rounds < len(roster) {
+			rounds++
+		}
+	}
+
+	swiss := &Swiss{
+		roster:    roster,
+		rounds:    rounds,
+		standings: make(map[*player.Player]*SwissStanding),
+		opponents: make(map[*player.Player][]*player.Player),
+		config:    newConfig(opts),
+	}
+	for _, p := range roster {
+		swiss.standings[p] = &SwissStanding{Player: p}
+	}
+	return swiss, nil
+}
+
+// IsOver reports whether every round has been played.
+func (s *Swiss) IsOver() bool {
+	return len(s.pairings) == s.rounds
+}
+
+// Rounds returns the pairings of every round played so far.
+func (s *Swiss) Rounds() [][]SwissPairing {
+	rounds := make([][]SwissPairing, len(s.pairings))
+	for i, round := range s.pairings {
+		rounds[i] = append([]SwissPairing(nil), round...)
+	}
+	return rounds
+}
+
+// PlayRound pairs the players for the next round and plays every game of it.
+// Players are ranked by score and paired top-down without rematches. When no such pairing is found within
+// maxPairingSteps, every player is paired with the highest-ranked opponent they have not met, falling back to
+// a rematch only for the players left without one. With an odd number of players, the lowest-ranked player
+// who has not had a bye yet receives one.
+//
+// Returns:
+//   - []SwissPairing: The pairings of the round, including their games.
+//   - error: ErrTournamentOver if every round has already been played.
+func (s *Swiss) PlayRound() ([]SwissPairing, error) {
+	if s.IsOver() {
+		return nil, ErrTournamentOver
+	}
+
+	//ranking players by score, keeping roster order among equal scores
+	ranked := make([]*player.Player, len(s.roster))
+	copy(ranked, s.roster)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return s.standings[ranked[i]].Score > s.standings[ranked[j]].Score
+	})
+
+	round := len(s.pairings)
+	var pairings []SwissPairing
+
+	//giving the bye to the lowest-ranked player without one
+	if len(ranked)%2 == 1 {
+		bye := len(ranked) - 1
+		for i := len(ranked) - 1; i >= 0; i-- {
+			if s.standings[ranked[i]].Byes == 0 {
+				bye = i
+				break
+			}
+		}
+		standing := s.standings[ranked[bye]]
+		standing.Byes++
+		standing.Score += SwissByeScore
+		pairings = append(pairings, SwissPairing{Round: round, PlayerA: ranked[bye]})
+		ranked = append(ranked[:bye], ranked[bye+1:]...)
+	}
+
+	steps := maxPairingSteps
+	pairs, ok := s.pairWithoutRematches(ranked, &steps)
+	if !ok {
+		pairs = s.pairGreedily(ranked)
+	}
+	for i := 0; i < len(pairs); i += 2 {
+		game := s.config.play(pairs[i], pairs[i+1])
+		s.record(game)
+		pairings = append(pairings, SwissPairing{Round: round, PlayerA: pairs[i], PlayerB: pairs[i+1], Game: game})
+	}
+	s.pairings = append(s.pairings, pairings)
+	return pairings, nil
+}
+
+// pairWithoutRematches orders ranked players into consecutive pairs of players who have not met before, pairing every
+// player with the highest-ranked available opponent. It backtracks when a choice leaves the rest unpairable,
+// giving up once it has tried *steps candidate opponents.
+//
+// Parameters:
+//   - ranked: The players to pair, in ranking order. There must be an even number of them.
+//   - steps: The number of candidate opponents the search may still try, decremented as it goes.
+//
+// Returns:
+//   - []*player.Player: The players ordered so that entries 2i and 2i+1 meet.
+//   - bool: false if every pairing contains a rematch or the search ran out of steps.
+func (s *Swiss) pairWithoutRematches(ranked []*player.Player, steps *int) ([]*player.Player, bool) {
+	if len(ranked) == 0 {
+		return nil, true
+	}
+	first := ranked[0]
+	for i := 1; i < len(ranked); i++ {
+		if s.haveMet(first, ranked[i]) {
+			continue
+		}
+		if *steps--; *steps < 0 {
+			return nil, false
+		}
+		rest := make([]*player.Player, 0, len(ranked)-2)
+		rest = append(rest, ranked[1:i]...)
+		rest = append(rest, ranked[i+1:]...)
+		if pairs, ok := s.pairWithoutRematches(rest, steps); ok {
+			return append([]*player.Player{first, ranked[i]}, pairs...), true
+		}
+	}
+	return nil, false
+}
+
+// pairGreedily orders ranked players into consecutive pairs without backtracking, pairing every player with
+// the highest-ranked available opponent they have not met, or with the highest-ranked available opponent if
+// they have met them all.
+//
+// Parameters:
+//   - ranked: The players to pair, in ranking order. There must be an even number of them.
+//
+// Returns:
+//   - []*player.Player: The players ordered so that entries 2i and 2i+1 meet.
+func (s *Swiss) pairGreedily(ranked []*player.Player) []*player.Player {
+	rest := append([]*player.Player(nil), ranked...)
+	pairs := make([]*player.Player, 0, len(ranked))
+	for len(rest) > 0 {
+		opponent := 1
+		for i := 1; i < len(rest); i++ {
+			if !s.haveMet(rest[0], rest[i]) {
+				opponent = i
+				break
+			}
+		}
+		pairs = append(pairs, rest[0], rest[opponent])
+		rest = append(rest[1:opponent], rest[opponent+1:]...)
+	}
+	return pairs
+}
+
+// haveMet reports whether two players have already played each other.
+func (s *Swiss) haveMet(a, b *player.Player) bool {
+	for _, opponent := range s.opponents[a] {
+		if opponent == b {
+			return true
+		}
+	}
+	return false
+}
+
+// record adds the result of a game to the standings.
+func (s *Swiss) record(game *Game) {
+	a, b := s.standings[game.PlayerA], s.standings[game.PlayerB]
+	s.opponents[game.PlayerA] = append(s.opponents[game.PlayerA], game.PlayerB)
+	s.opponents[game.PlayerB] = append(s.opponents[game.PlayerB], game.PlayerA)
+
+	switch {
+	case game.Result.Outcome != match.Win:
+		a.Draws++
+		b.Draws++
+		a.Score += SwissDrawScore
+		b.Score += SwissDrawScore
+	case game.Result.Winner == game.PlayerA:
+		a.Wins++
+		b.Losses++
+		a.Score += SwissWinScore
+	default:
+		b.Wins++
+		a.Losses++
+		b.Score += SwissWinScore
+	}
+}
+
+// Run plays every remaining round and returns the final standings.
+//
+// Returns:
+//   - []SwissStanding: The standings, from first to last place.
+func (s *Swiss) Run() []SwissStanding {
+	for !s.IsOver() {
+		s.PlayRound()
+	}
+	return s.Standings()
+}
+
+// Standings returns the current standings, ordered by score, then Buchholz, then Buchholz Cut 1, then roster order.
+//
+// Returns:
+//   - []SwissStanding: The standings, from first to last place.
+//
+// Example:
+//   for place, standing := range swiss.Standings() {
+//       fmt.Printf("%d. %.1f (Buchholz %.1f)\n", place+1, standing.Score, standing.Buchholz)
+//   }
+func (s *Swiss) Standings() []SwissStanding {
+	table := make([]SwissStanding, len(s.roster))
+	for i, p := range s.roster {
+		table[i] = *s.standings[p]
+
+		//computing the Buchholz tie-breaks from the opponents' current scores
+		lowest := 0.0
+		for j, opponent := range s.opponents[p] {
+			score := s.standings[opponent].Score
+			table[i].Buchholz += score
+			if j == 0 || score < lowest {
+				lowest = score
+			}
+		}
+		table[i].BuchholzCut1 = table[i].Buchholz - lowest
+	}
+	sort.SliceStable(table, func(i, j int) bool {
+		if table[i].Score != table[j].Score {
+			return table[i].Score > table[j].Score
+		}
+		if table[i].Buchholz != table[j].Buchholz {
+			return table[i].Buchholz > table[j].Buchholz
+		}
+		return table[i].BuchholzCut1 > table[j].BuchholzCut1
+	})
+	return table
+}
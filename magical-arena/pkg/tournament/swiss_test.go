@@ -0,0 +1,124 @@
+package tournament
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestSwiss tests pairing, byes and tie-breaks of a Swiss tournament.
+func TestSwiss(t *testing.T) {
+	// TEST 1: 7 players default to 3 rounds of 3 games and a bye
+	roster := newRoster(7)
+	swiss, _ := NewSwiss(roster, 0, WithSeed(11))
+	standings := swiss.Run()
+	rounds := swiss.Rounds()
+	shapeOK := len(rounds) == 3
+	for _, round := range rounds {
+		games, byes := 0, 0
+		for _, pairing := range round {
+			if pairing.PlayerB == nil {
+				byes++
+			} else {
+				games++
+			}
+		}
+		shapeOK = shapeOK && games == 3 && byes == 1
+	}
+	if !shapeOK || !swiss.IsOver() {
+		t.Errorf(redColor+"Expected 3 rounds of 3 games and a bye, got %+v"+resetColor, rounds)
+	} else {
+		fmt.Println(greenColor + "TestSwiss : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: nobody meets the same opponent twice or receives two byes
+	met := make(map[string]bool)
+	byes := make(map[string]int)
+	repeated := false
+	for _, round := range rounds {
+		for _, pairing := range round {
+			if pairing.PlayerB == nil {
+				byes[fmt.Sprintf("%p", pairing.PlayerA)]++
+				repeated = repeated || byes[fmt.Sprintf("%p", pairing.PlayerA)] > 1
+				continue
+			}
+			key := fmt.Sprintf("%p-%p", pairing.PlayerA, pairing.PlayerB)
+			reverse := fmt.Sprintf("%p-%p", pairing.PlayerB, pairing.PlayerA)
+			repeated = repeated || met[key] || met[reverse]
+			met[key] = true
+		}
+	}
+	if repeated {
+		t.Errorf(redColor+"Expected no rematches or repeated byes, got %+v"+resetColor, rounds)
+	} else {
+		fmt.Println(greenColor + "TestSwiss : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: standings are ordered by score then Buchholz, and scores add up to one point per game and bye
+	total := 0.0
+	ordered := true
+	for i, standing := range standings {
+		total += standing.Score
+		if i > 0 {
+			previous := standings[i-1]
+			ordered = ordered && (previous.Score > standing.Score || previous.Score == standing.Score && previous.Buchholz >= standing.Buchholz)
+		}
+	}
+	if !ordered || total != 3*4 {
+		t.Errorf(redColor+"Expected ordered standings totalling 12 points, got %+v"+resetColor, standings)
+	} else {
+		fmt.Println(greenColor + "TestSwiss : Test3 : Passed" + resetColor)
+	}
+
+	// TEST 4: no further rounds can be played
+	if _, err := swiss.PlayRound(); err != ErrTournamentOver {
+		t.Errorf(redColor+"Expected ErrTournamentOver, got %v"+resetColor, err)
+	} else {
+		fmt.Println(greenColor + "TestSwiss : Test4 : Passed" + resetColor)
+	}
+
+	// TEST 5: more rounds than a player has opponents is rejected
+	if _, err := NewSwiss(newRoster(6), 6); err != ErrTooManyRounds {
+		t.Errorf(redColor+"Expected ErrTooManyRounds, got %v"+resetColor, err)
+	} else {
+		fmt.Println(greenColor + "TestSwiss : Test5 : Passed" + resetColor)
+	}
+
+	// TEST 6: the longest tournaments allowed, where rematch-free pairings may not exist, finish in bounded time
+	start := time.Now()
+	for _, size := range []int{16, 17, 24} {
+		swiss, err := NewSwiss(newRoster(size), size-1, WithSeed(int64(size)))
+		if err != nil {
+			t.Errorf(redColor+"Expected %d rounds for %d players, got %v"+resetColor, size-1, size, err)
+			return
+		}
+		swiss.Run()
+		if len(swiss.Rounds()) != size-1 {
+			t.Errorf(redColor+"Expected %d rounds, got %d"+resetColor, size-1, len(swiss.Rounds()))
+			return
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf(redColor+"Expected the tournaments to finish within 5s, took %v"+resetColor, elapsed)
+	} else {
+		fmt.Println(greenColor + "TestSwiss : Test6 : Passed" + resetColor)
+	}
+
+	// TEST 7: a round without any rematch-free pairing is paired quickly, with as few rematches as the greedy order allows.
+	// The three lowest-ranked players have met everyone else, so the other 21 would have to pair among themselves.
+	roster = newRoster(24)
+	swiss, _ = NewSwiss(roster, 3)
+	for _, trio := range roster[21:] {
+		for _, other := range roster[:21] {
+			swiss.opponents[trio] = append(swiss.opponents[trio], other)
+			swiss.opponents[other] = append(swiss.opponents[other], trio)
+		}
+	}
+	start = time.Now()
+	pairings, err := swiss.PlayRound()
+	if elapsed := time.Since(start); err != nil || len(pairings) != 12 || elapsed > 5*time.Second {
+		t.Errorf(redColor+"Expected 12 games within 5s, got %d after %v (%v)"+resetColor, len(pairings), elapsed, err)
+	} else {
+		fmt.Println(greenColor + "TestSwiss : Test7 : Passed" + resetColor)
+	}
+}
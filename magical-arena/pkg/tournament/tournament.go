@@ -0,0 +1,154 @@
+package tournament
+
+import (
+	"errors"
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"time"
+)
+
+// ErrTooFewPlayers is returned when a tournament is created with fewer than two players.
+var ErrTooFewPlayers = errors.New("a tournament needs at least two players")
+
+// ErrDuplicatePlayer is returned when a tournament is created with a player listed more than once,
+// who would have to fight themselves.
+var ErrDuplicatePlayer = errors.New("a player is listed more than once")
+
+// ErrTooManyRounds is returned when a tournament is created with more rounds than its players can play
+// without meeting an opponent twice.
+var ErrTooManyRounds = errors.New("too many rounds for the number of players")
+
+// ErrTournamentOver is returned when a game is requested from a tournament that has already finished.
+var ErrTournamentOver = errors.New("tournament is already over")
+
+// Game is a single match played as part of a tournament.
+type Game struct {
+	// PlayerA is the first player of the match, who is PlayerA of match.NewMatch.
+	PlayerA *player.Player
+
+	// PlayerB is the second player of the match, who is PlayerB of match.NewMatch.
+	PlayerB *player.Player
+
+	// Seed is the seed the match was played with.
+	Seed int64
+
+	// Result is the result of the match.
+	Result match.Result
+
+	// DamageA is the total damage dealt by PlayerA.
+	DamageA int
+
+	// DamageB is the total damage dealt by PlayerB.
+	DamageB int
+}
+
+// config holds the settings shared by every tournament format.
+type config struct {
+	// seed is the base seed of the tournament. The n-th game played uses seed+n.
+	seed int64
+
+	// matchOptions are extra options applied to every match.
+	matchOptions []match.Option
+
+	// homeAndAway makes every pair of league players meet twice.
+	homeAndAway bool
+
+	// tieBreakers rank league players who are level on points.
+	tieBreakers []TieBreaker
+
+	// noGrandFinalReset makes the grand final of a double-elimination bracket a single game.
+	noGrandFinalReset bool
+
+	// games is the number of games played so far.
+	games int
+}
+
+// Option configures optional behaviour of a tournament.
+type Option func(*config)
+
+// WithSeed sets the base seed of the tournament. The n-th game of the tournament is played with seed+n,
+// so a tournament created with the same roster and seed plays out identically.
+//
+// Parameters:
+//   - seed: The base seed of the tournament.
+//
+// Returns:
+//   - Option: An option to pass to a tournament constructor.
+//
+// Example:
+//   bracket, err := NewSingleElimination(roster, WithSeed(42))
+func WithSeed(seed int64) Option {
+	return func(c *config) {
+		c.seed = seed
+	}
+}
+
+// WithMatchOptions applies the given match options, such as match.WithMaxRounds, to every match of the tournament.
+//
+// Parameters:
+//   - opts: The match options.
+//
+// Returns:
+//   - Option: An option to pass to a tournament constructor.
+//
+// Example:
+//   bracket, err := NewSingleElimination(roster, WithMatchOptions(match.WithMaxRounds(500)))
+func WithMatchOptions(opts ...match.Option) Option {
+	return func(c *config) {
+		c.matchOptions = append(c.matchOptions, opts...)
+	}
+}
+
+// newConfig returns the default configuration with the given options applied.
+// Unless a seed is supplied, the tournament is seeded from the current time.
+func newConfig(opts []Option) *config {
+	c := &config{seed: time.Now().UnixNano(), tieBreakers: DefaultTieBreakers}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// play runs a match between two players with the next seed of the tournament.
+//
+// Parameters:
+//   - playerA: A pointer to the first player.
+//   - playerB: A pointer to the second player.
+//   - extra: Match options for this game only, applied after the tournament's match options.
+//
+// Returns:
+//   - *Game: The game that was played.
+func (c *config) play(playerA, playerB *player.Player, extra ...match.Option) *Game {
+	game := &Game{PlayerA: playerA, PlayerB: playerB, Seed: c.seed + int64(c.games)}
+	c.games++
+
+	options := append([]match.Option{match.WithSeed(game.Seed)}, c.matchOptions...)
+	options = append(options, extra...)
+	events, result := match.ConductMatch(match.NewMatch(playerA, playerB, options...))
+	game.Result = result
+	idA := player.GetPlayerID(playerA)
+	for _, event := range events {
+		if event.AttackerID == idA {
+			game.DamageA += event.Damage
+		} else {
+			game.DamageB += event.Damage
+		}
+	}
+	return game
+}
+
+// checkRoster checks that a roster has at least two players and lists each of them once, by ID.
+func checkRoster(roster []*player.Player) error {
+	if len(roster) < 2 {
+		return ErrTooFewPlayers
+	}
+	ids := make(map[string]bool, len(roster))
+	for _, p := range roster {
+		id := player.GetPlayerID(p)
+		if ids[id] {
+			return ErrDuplicatePlayer
+		}
+		ids[id] = true
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ReadKeys reads single key presses from r until it fails, reaches its end or ctx is cancelled, then closes the channel.
+// The terminal must be in raw mode (see RawMode) for keys to arrive without Enter. Reads from a terminal in raw mode
+// time out regularly, so that the reader stops soon after ctx is cancelled instead of waiting for another key.
+//
+// Parameters:
+//   - ctx: The context stopping the reader.
+//   - r: The reader to read keys from, usually os.Stdin.
+//
+// Returns:
+//   - <-chan byte: The keys, in the order they were pressed.
+//
+// Example:
+//   keys := ReadKeys(ctx, os.Stdin)
+func ReadKeys(ctx context.Context, r io.Reader) <-chan byte {
+	keys := make(chan byte)
+	polled := isTerminal(r)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 1)
+		for ctx.Err() == nil {
+			n, err := r.Read(buf)
+			if n > 0 {
+				select {
+				case keys <- buf[0]:
+				case <-ctx.Done():
+					return
+				}
+			}
+			//a terminal read that timed out without a key reports the end of the input
+			if err != nil && !(polled && err == io.EOF) {
+				return
+			}
+		}
+	}()
+	return keys
+}
+
+// isTerminal reports whether r is a terminal.
+func isTerminal(r io.Reader) bool {
+	file, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// RawMode puts the terminal on standard input in raw mode with stty, so that key presses are delivered
+// immediately and not echoed. Reads return after a tenth of a second without a key. It fails when standard input is not a terminal or stty is not available.
+//
+// Returns:
+//   - func(): A function restoring the previous terminal settings.
+//   - error: An error if the terminal could not be switched to raw mode.
+//
+// Example:
+//   restore, err := RawMode()
+//   if err == nil {
+//       defer restore()
+//   }
+func RawMode() (func(), error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("-icanon", "-echo", "min", "0", "time", "1"); err != nil {
+		return nil, err
+	}
+	return func() {
+		stty(strings.TrimSpace(saved))
+	}, nil
+}
+
+// stty runs stty on standard input with the given arguments and returns its output.
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
@@ -0,0 +1,326 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"strings"
+	"time"
+)
+
+// ANSI escape sequences used to draw the screen
+const (
+	enterAltScreen = "\033[?1049h\033[?25l"
+	leaveAltScreen = "\033[?25h\033[?1049l"
+	clearScreen    = "\033[H\033[2J"
+	redColor       = "\033[31m"
+	greenColor     = "\033[32m"
+	yellowColor    = "\033[33m"
+	cyanColor      = "\033[36m"
+	boldText       = "\033[1m"
+	resetColor     = "\033[0m"
+)
+
+// Default settings of a Viewer.
+const (
+	DefaultDelay    = 500 * time.Millisecond
+	DefaultLogLines = 10
+	DefaultBarWidth = 30
+)
+
+// the fastest and slowest speeds the speed controls can reach
+const (
+	minDelay = 10 * time.Millisecond
+	maxDelay = 5 * time.Second
+)
+
+// config holds the settings of a Viewer.
+type config struct {
+	delay    time.Duration
+	logLines int
+	barWidth int
+}
+
+// Option configures optional behaviour of a Viewer created by New.
+type Option func(*config)
+
+// WithDelay sets the time between two rounds when the match is running. The speed controls halve and double it.
+//
+// Parameters:
+//   - delay: The time between two rounds.
+//
+// Returns:
+//   - Option: An option to pass to New.
+//
+// Example:
+//   viewer := New(myMatch, os.Stdout, WithDelay(time.Second))
+func WithDelay(delay time.Duration) Option {
+	return func(c *config) {
+		c.delay = delay
+	}
+}
+
+// WithLogLines sets the number of rounds shown in the combat log. A value of 0 or less keeps DefaultLogLines.
+//
+// Parameters:
+//   - lines: The number of log lines.
+//
+// Returns:
+//   - Option: An option to pass to New.
+//
+// Example:
+//   viewer := New(myMatch, os.Stdout, WithLogLines(20))
+func WithLogLines(lines int) Option {
+	return func(c *config) {
+		c.logLines = lines
+	}
+}
+
+// WithBarWidth sets the width of the health bars, in characters. A value of 0 or less keeps DefaultBarWidth.
+//
+// Parameters:
+//   - width: The width of the health bars.
+//
+// Returns:
+//   - Option: An option to pass to New.
+//
+// Example:
+//   viewer := New(myMatch, os.Stdout, WithBarWidth(50))
+func WithBarWidth(width int) Option {
+	return func(c *config) {
+		c.barWidth = width
+	}
+}
+
+// Viewer animates a match round by round on a full-screen terminal, with health bars, dice rolls,
+// damage and a scrolling combat log. It is controlled with single key presses:
+// space or p pauses and resumes, n plays a single round while paused, + and - change the speed, and q quits.
+type Viewer struct {
+	config
+	match  *match.Match
+	out    io.Writer
+	paused bool
+	speed  time.Duration
+}
+
+// New creates a Viewer for a match. The match may already be partly played.
+//
+// Parameters:
+//   - m: A pointer to the match to animate.
+//   - out: The terminal to draw on.
+//   - opts: Optional settings such as WithDelay, WithLogLines or WithBarWidth.
+//
+// Returns:
+//   - *Viewer: A pointer to the newly created Viewer.
+//
+// Example:
+//   viewer := New(match.NewMatch(player1, player2), os.Stdout)
+func New(m *match.Match, out io.Writer, opts ...Option) *Viewer {
+	c := config{delay: DefaultDelay, logLines: DefaultLogLines, barWidth: DefaultBarWidth}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.logLines <= 0 {
+		c.logLines = DefaultLogLines
+	}
+	if c.barWidth <= 0 {
+		c.barWidth = DefaultBarWidth
+	}
+	return &Viewer{config: c, match: m, out: out, speed: c.delay}
+}
+
+// Run plays the match to the end on the full-screen terminal, one round per delay, reacting to the keys pressed.
+// Once the match is over the final screen stays up until a key is pressed, then the terminal is restored.
+// Quitting or cancelling ctx aborts the match. If the keys end while the match is paused, it resumes on its own.
+// Run stops reading keys before it returns, so keys must be a terminal in raw mode or a reader that ends.
+//
+// Parameters:
+//   - ctx: The context controlling cancellation of the match.
+//   - keys: The reader of the keys pressed by the user, usually os.Stdin; nil when there is no keyboard.
+//
+// Returns:
+//   - match.Result: The result of the match.
+//
+// Example:
+//   restore, _ := RawMode()
+//   result := viewer.Run(context.Background(), os.Stdin)
+//   restore()
+func (v *Viewer) Run(ctx context.Context, keys io.Reader) match.Result {
+	fmt.Fprint(v.out, enterAltScreen)
+	defer fmt.Fprint(v.out, leaveAltScreen)
+
+	var pressed <-chan byte
+	if keys != nil {
+		readCtx, stop := context.WithCancel(ctx)
+		reader := ReadKeys(readCtx, keys)
+		pressed = reader
+		defer func() {
+			//waiting for the reader to stop so that no key is read after the match
+			stop()
+			for range reader {
+			}
+		}()
+	}
+
+	v.draw()
+	for !v.match.IsOver() {
+		//waiting for the next round unless paused
+		var tick <-chan time.Time
+		timer := time.NewTimer(v.speed)
+		if !v.paused {
+			tick = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			v.match.Abort()
+		case key, ok := <-pressed:
+			if !ok {
+				//without a keyboard the match could never be resumed
+				pressed = nil
+				v.paused = false
+			} else {
+				v.handleKey(key)
+			}
+		case <-tick:
+			v.match.NextRound()
+		}
+		timer.Stop()
+		v.draw()
+	}
+
+	//keeping the final screen up until a key is pressed
+	if pressed != nil {
+		select {
+		case <-ctx.Done():
+		case <-pressed:
+		}
+	}
+	return v.match.Result()
+}
+
+// handleKey applies a single key press.
+func (v *Viewer) handleKey(key byte) {
+	switch key {
+	case ' ', 'p':
+		v.paused = !v.paused
+	case 'n', 's':
+		if v.paused {
+			v.match.NextRound()
+		}
+	case '+', '=':
+		if v.speed /= 2; v.speed < minDelay {
+			v.speed = minDelay
+		}
+	case '-', '_':
+		if v.speed *= 2; v.speed > maxDelay {
+			v.speed = maxDelay
+		}
+	case 'q', 3:
+		v.match.Abort()
+	}
+}
+
+// draw redraws the whole screen.
+func (v *Viewer) draw() {
+	fmt.Fprint(v.out, clearScreen+v.Frame())
+}
+
+// Frame returns the current screen of the viewer, without the escape sequence that clears the terminal.
+//
+// Returns:
+//   - string: The lines of the screen.
+//
+// Example:
+//   fmt.Print(viewer.Frame())
+func (v *Viewer) Frame() string {
+	var b strings.Builder
+	state := v.match.State()
+	events := v.match.RoundEvents()
+
+	//title and status
+	status := fmt.Sprintf("running, speed %.2gx", float64(v.delay)/float64(v.speed))
+	switch {
+	case state.Over:
+		status = "over"
+	case v.paused:
+		status = "paused"
+	}
+	fmt.Fprintf(&b, boldText+cyanColor+"Magical Arena - Round %d"+resetColor+"  [%s]\r\n\r\n", state.Round, status)
+
+	//health bars
+	nameA, maxA, _, _ := player.GetPlayerBaseAttributes(v.match.PlayerA)
+	nameB, maxB, _, _ := player.GetPlayerBaseAttributes(v.match.PlayerB)
+	width := len(nameA)
+	if len(nameB) > width {
+		width = len(nameB)
+	}
+	fmt.Fprintf(&b, "%-*s %s %d/%d\r\n", width, nameA, HealthBar(state.HealthA, maxA, v.barWidth), state.HealthA, maxA)
+	fmt.Fprintf(&b, "%-*s %s %d/%d\r\n\r\n", width, nameB, HealthBar(state.HealthB, maxB, v.barWidth), state.HealthB, maxB)
+
+	//dice and damage of the last round
+	if len(events) > 0 {
+		last := events[len(events)-1]
+		fmt.Fprintf(&b, "%s rolled %d (attack %d), %s rolled %d (defence %d): "+boldText+"%d damage"+resetColor+"\r\n\r\n",
+			last.Attacker, last.AttackRoll, last.Attack, last.Defender, last.DefenceRoll, last.Defence, last.Damage)
+	} else {
+		b.WriteString("Waiting for the first round...\r\n\r\n")
+	}
+
+	//scrolling combat log
+	b.WriteString("Combat log:\r\n")
+	first := len(events) - v.logLines
+	if first < 0 {
+		first = 0
+	}
+	for _, event := range events[first:] {
+		fmt.Fprintf(&b, "  %4d  %s\r\n", event.Round, event.String())
+	}
+	b.WriteString("\r\n")
+
+	//result or controls
+	if state.Over {
+		b.WriteString(boldText + greenColor + "Match result: " + state.Result.String() + resetColor + "\r\n")
+		b.WriteString("Press any key to exit.\r\n")
+	} else {
+		b.WriteString("space: pause/resume  n: step  +/-: speed  q: quit\r\n")
+	}
+	return b.String()
+}
+
+// HealthBar draws a health bar of the given width, green above half health, yellow above a quarter and red below.
+//
+// Parameters:
+//   - health: The current health.
+//   - maxHealth: The starting health, which fills the whole bar.
+//   - width: The width of the bar, in characters; a negative width draws an empty bar.
+//
+// Returns:
+//   - string: The health bar, with ANSI colors.
+//
+// Example:
+//   fmt.Println(HealthBar(40, 100, 20)) // [████████░░░░░░░░░░░░] in yellow
+func HealthBar(health, maxHealth, width int) string {
+	if width < 0 {
+		width = 0
+	}
+	filled := 0
+	if maxHealth > 0 && health > 0 {
+		//rounding up so that a player who is still standing never shows an empty bar
+		filled = (health*width + maxHealth - 1) / maxHealth
+		if filled > width {
+			filled = width
+		}
+	}
+
+	color := greenColor
+	switch {
+	case 4*health <= maxHealth:
+		color = redColor
+	case 2*health <= maxHealth:
+		color = yellowColor
+	}
+	return "[" + color + strings.Repeat("█", filled) + resetColor + strings.Repeat("░", width-filled) + "]"
+}
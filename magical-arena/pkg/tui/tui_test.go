@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"magical-arena/pkg/match"
+	"magical-arena/pkg/player"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestHealthBar tests the fill and color of health bars.
+func TestHealthBar(t *testing.T) {
+	// TEST 1: the bar fills in proportion to health, rounding up, with the color of the health band
+	cases := []struct {
+		health, maxHealth int
+		filled            int
+		color             string
+	}{
+		{100, 100, 10, greenColor},
+		{40, 100, 4, yellowColor},
+		{1, 100, 1, redColor},
+		{0, 100, 0, redColor},
+	}
+	for _, c := range cases {
+		bar := HealthBar(c.health, c.maxHealth, 10)
+		if strings.Count(bar, "█") != c.filled || strings.Count(bar, "░") != 10-c.filled || !strings.Contains(bar, c.color) {
+			t.Errorf(redColor+"Unexpected bar %q for %d/%d"+resetColor, bar, c.health, c.maxHealth)
+			return
+		}
+	}
+	fmt.Println(greenColor + "TestHealthBar : Test1 : Passed" + resetColor)
+
+	// TEST 2: a negative width draws an empty bar instead of panicking
+	if bar := HealthBar(50, 100, -3); bar != "["+yellowColor+resetColor+"]" {
+		t.Errorf(redColor+"Unexpected bar %q for a negative width"+resetColor, bar)
+	} else {
+		fmt.Println(greenColor + "TestHealthBar : Test2 : Passed" + resetColor)
+	}
+}
+
+// TestViewer tests drawing and controlling a match.
+func TestViewer(t *testing.T) {
+	playerA := player.NewPlayer("Hero", 100, 10, 5)
+	playerB := player.NewPlayer("Brute", 80, 8, 6)
+
+	// TEST 1: the frame shows both fighters, the last dice and damage, and the log of the latest rounds
+	m := match.NewMatch(playerA, playerB, match.WithSeed(1))
+	for i := 0; i < 4; i++ {
+		m.NextRound()
+	}
+	viewer := New(m, &bytes.Buffer{}, WithLogLines(3))
+	frame := viewer.Frame()
+	last := m.RoundEvents()[3]
+	if !strings.Contains(frame, "Round 4") || !strings.Contains(frame, "Hero") || !strings.Contains(frame, "Brute") ||
+		!strings.Contains(frame, fmt.Sprintf("rolled %d (attack %d)", last.AttackRoll, last.Attack)) ||
+		!strings.Contains(frame, last.String()) || strings.Contains(frame, m.RoundEvents()[0].String()+"\r") {
+		t.Errorf(redColor+"Unexpected frame %q"+resetColor, frame)
+	} else {
+		fmt.Println(greenColor + "TestViewer : Test1 : Passed" + resetColor)
+	}
+
+	// TEST 2: while paused only the step key plays rounds, and quitting aborts the match
+	m = match.NewMatch(playerA, playerB, match.WithSeed(1))
+	var out bytes.Buffer
+	keys, typing := io.Pipe()
+	done := make(chan match.Result)
+	go func() {
+		done <- New(m, &out, WithDelay(time.Hour)).Run(context.Background(), keys)
+	}()
+	for _, key := range []byte{' ', 'n', 'n', 'q', 'x'} {
+		typing.Write([]byte{key})
+	}
+	typing.Close()
+	result := <-done
+	if result.Outcome != match.Aborted || result.Rounds != 2 || !strings.Contains(out.String(), "Match aborted after 2 rounds") {
+		t.Errorf(redColor+"Unexpected result %v"+resetColor, result)
+	} else {
+		fmt.Println(greenColor + "TestViewer : Test2 : Passed" + resetColor)
+	}
+
+	// TEST 3: without a keyboard the match plays to the end on its own
+	m = match.NewMatch(playerA, playerB, match.WithSeed(1))
+	result = New(m, &bytes.Buffer{}, WithDelay(time.Microsecond)).Run(context.Background(), nil)
+	if result.Outcome != match.Win {
+		t.Errorf(redColor+"Expected the match to be won, got %v"+resetColor, result)
+	} else {
+		fmt.Println(greenColor + "TestViewer : Test3 : Passed" + resetColor)
+	}
+
+	// TEST 4: when the keys end while the match is paused, the match resumes instead of waiting forever
+	m = match.NewMatch(playerA, playerB, match.WithSeed(1))
+	go func() {
+		done <- New(m, &bytes.Buffer{}, WithDelay(time.Microsecond)).Run(context.Background(), strings.NewReader(" "))
+	}()
+	select {
+	case result = <-done:
+		if result.Outcome != match.Win {
+			t.Errorf(redColor+"Expected the match to be won, got %v"+resetColor, result)
+		} else {
+			fmt.Println(greenColor + "TestViewer : Test4 : Passed" + resetColor)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf(redColor + "The paused match never ended after the keys ended" + resetColor)
+	}
+
+	// TEST 5: cancelling the context aborts the match, and no key is read once Run has returned
+	m = match.NewMatch(playerA, playerB, match.WithSeed(1))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	reader := &idleKeys{}
+	result = New(m, &bytes.Buffer{}, WithDelay(time.Hour)).Run(ctx, reader)
+	readsAtReturn := atomic.LoadInt32(&reader.reads)
+	time.Sleep(20 * time.Millisecond)
+	if result.Outcome != match.Aborted || atomic.LoadInt32(&reader.reads) != readsAtReturn {
+		t.Errorf(redColor+"Unexpected result %v or keys read after Run returned"+resetColor, result)
+	} else {
+		fmt.Println(greenColor + "TestViewer : Test5 : Passed" + resetColor)
+	}
+
+	// TEST 6: log line counts and bar widths of 0 or less fall back to the defaults
+	m = match.NewMatch(playerA, playerB, match.WithSeed(1))
+	for i := 0; i < 12 && !m.IsOver(); i++ {
+		m.NextRound()
+	}
+	frame = New(m, &bytes.Buffer{}, WithLogLines(-5), WithBarWidth(-1)).Frame()
+	defaults := New(m, &bytes.Buffer{}).Frame()
+	if frame != defaults {
+		t.Errorf(redColor+"Unexpected frame %q, expected %q"+resetColor, frame, defaults)
+	} else {
+		fmt.Println(greenColor + "TestViewer : Test6 : Passed" + resetColor)
+	}
+}
+
+// idleKeys is a keyboard on which no key is ever pressed, whose reads time out like a terminal in raw mode.
+type idleKeys struct {
+	reads int32
+}
+
+// Read waits briefly and returns no key.
+func (k *idleKeys) Read(p []byte) (int, error) {
+	atomic.AddInt32(&k.reads, 1)
+	time.Sleep(time.Millisecond)
+	return 0, nil
+}
+
+// TestMain runs the main testing suite.
+func TestMain(m *testing.M) {
+	fmt.Println("Testing tui package...")
+	Result := m.Run()
+	fmt.Println("Testing complete.")
+	os.Exit(Result)
+}